@@ -15,9 +15,32 @@ type Config struct {
 
 	ServerPort string `mapstructure:"PORT"`
 
+	MigrateOnStartup bool `mapstructure:"MIGRATE_ON_STARTUP"`
+
 	TokenSecret    string        `mapstructure:"TOKEN_SECRET"`
 	TokenExpiresIn time.Duration `mapstructure:"TOKEN_EXPIRED_IN"`
 	TokenMaxAge    int           `mapstructure:"TOKEN_MAXAGE"`
+
+	// The token cookie is only sent over HTTPS when TokenSecure is set.
+	TokenSecure bool `mapstructure:"TOKEN_SECURE"`
+
+	// The national admin created on first start; no account is created
+	// while AdminPassword is empty.
+	AdminUsername string `mapstructure:"ADMIN_USERNAME"`
+	AdminPassword string `mapstructure:"ADMIN_PASSWORD"`
+
+	// Deleted reports are purged after TrashRetentionDays; 0 keeps them forever.
+	TrashRetentionDays int           `mapstructure:"TRASH_RETENTION_DAYS"`
+	TrashPurgeInterval time.Duration `mapstructure:"TRASH_PURGE_INTERVAL"`
+
+	// Activity averages are rounded half_up, half_even, down or up to
+	// AveragePrecision decimals when a report is saved. Stored reports keep
+	// the rounding they were saved with until "recompute-stats" is run.
+	AverageRounding  string `mapstructure:"AVERAGE_ROUNDING"`
+	AveragePrecision int    `mapstructure:"AVERAGE_PRECISION"`
+
+	// The report of a month is due on this day of the next month.
+	ReportDeadlineDay int `mapstructure:"REPORT_DEADLINE_DAY"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -25,6 +48,10 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigType("env")
 	viper.SetConfigName("app")
 
+	viper.SetDefault("AVERAGE_ROUNDING", "half_up")
+	viper.SetDefault("AVERAGE_PRECISION", 2)
+	viper.SetDefault("REPORT_DEADLINE_DAY", 5)
+
 	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"net/http"
+	"reports/data/request"
+	"reports/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ActivityController struct {
+	activityService service.ActivityService
+}
+
+func NewActivityController(activityService service.ActivityService) *ActivityController {
+	return &ActivityController{activityService: activityService}
+}
+
+func (controller *ActivityController) Create(ctx *gin.Context) {
+	var req request.ActivityCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	activity, err := controller.activityService.Create(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to create activity", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"activity": activity})
+}
+
+func (controller *ActivityController) FindById(ctx *gin.Context) {
+	activityId, err := strconv.Atoi(ctx.Param("activityId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+
+	activity, err := controller.activityService.FindById(ctx.Request.Context(), activityId)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Activity not found", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"activity": activity})
+}
+
+// FindAll lists the active activities in display order; with
+// include_inactive=true the retired ones are listed too.
+func (controller *ActivityController) FindAll(ctx *gin.Context) {
+	activities, err := controller.activityService.FindAll(ctx.Request.Context(), ctx.Query("include_inactive") == "true")
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to fetch activities", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"activities": activities})
+}
+
+func (controller *ActivityController) Update(ctx *gin.Context) {
+	var req request.ActivityUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	activityId, err := strconv.Atoi(ctx.Param("activityId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		return
+	}
+
+	req.Id = activityId
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := controller.activityService.Update(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to update activity", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Activity updated successfully"})
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"net/http"
+	"reports/model"
+	"reports/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsController struct {
+	analyticsService service.AnalyticsService
+}
+
+func NewAnalyticsController(analyticsService service.AnalyticsService) *AnalyticsController {
+	return &AnalyticsController{analyticsService: analyticsService}
+}
+
+// Summary returns the totals and averages of every activity over the
+// reports matching the report list filters, grouped with group_by=area,
+// church, worker and/or period. period=month, quarter or year sets the
+// size of a period.
+func (controller *AnalyticsController) Summary(ctx *gin.Context) {
+	filter, err := parseReportFilters(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	groupBy, err := model.ParseGroupBy(ctx.Query("group_by"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	period := ctx.DefaultQuery("period", model.PeriodMonth)
+	if !model.IsValidPeriodGranularity(period) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period, use month, quarter or year"})
+		return
+	}
+
+	query := model.AnalyticsQuery{Filter: filter, GroupBy: groupBy, Period: period}
+	summary, err := controller.analyticsService.Summary(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to sum up reports", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"summary": summary})
+}
+
+// Trends returns the monthly series of one activity, ?activity=
+// worship_service, as its total, weekly average or reported weeks with
+// measure=total, average or weeks. The report list filters apply; the
+// worker, church or area filter sets the scope. month_from and month_to
+// default to the last 12 months.
+func (controller *AnalyticsController) Trends(ctx *gin.Context) {
+	filter, err := parseReportFilters(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := model.TrendQuery{
+		Activity: ctx.Query("activity"),
+		Measure:  ctx.DefaultQuery("measure", model.MeasureTotal),
+		From:     filter.MonthFrom,
+		To:       filter.MonthTo,
+	}
+
+	if query.To.IsZero() {
+		query.To = currentMonth()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.AddMonths(-11)
+	}
+
+	// The series sets its own month range
+	filter.MonthFrom, filter.MonthTo = model.Period{}, model.Period{}
+	query.Filter = filter
+
+	if err := query.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, err := controller.analyticsService.Trends(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to read trends", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"trends": series})
+}
+
+// Compliance lists the active workers who have not submitted their report
+// for month_of, the last month by default, with per-area compliance and
+// each worker's streak and delay over the last history months.
+func (controller *AnalyticsController) Compliance(ctx *gin.Context) {
+	query := model.ComplianceQuery{
+		AreaId:  parseId(ctx.Query("area_id")),
+		History: model.DefaultComplianceHistory,
+	}
+
+	if value := ctx.Query("month_of"); value != "" {
+		month, err := model.ParsePeriod(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		query.Month = month
+	} else {
+		query.Month = currentMonth().AddMonths(-1)
+	}
+
+	if value := ctx.Query("history"); value != "" {
+		history, err := strconv.Atoi(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid history"})
+			return
+		}
+		query.History = history
+	}
+
+	if err := query.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	compliance, err := controller.analyticsService.Compliance(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to read compliance", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"compliance": compliance})
+}
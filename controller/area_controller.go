@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"net/http"
+	"reports/data/request"
+	"reports/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AreaController struct {
+	areaService service.AreaService
+}
+
+func NewAreaController(areaService service.AreaService) *AreaController {
+	return &AreaController{areaService: areaService}
+}
+
+func (controller *AreaController) Create(ctx *gin.Context) {
+	var req request.AreaCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	area, err := controller.areaService.Create(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to create area", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"area": area})
+}
+
+func (controller *AreaController) FindById(ctx *gin.Context) {
+	areaId, err := strconv.Atoi(ctx.Param("areaId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid area ID"})
+		return
+	}
+
+	area, err := controller.areaService.FindById(ctx.Request.Context(), areaId)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Area not found", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"area": area})
+}
+
+func (controller *AreaController) FindAll(ctx *gin.Context) {
+	areas, err := controller.areaService.FindAll(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to fetch areas", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"areas": areas})
+}
+
+func (controller *AreaController) Update(ctx *gin.Context) {
+	var req request.AreaUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	areaId, err := strconv.Atoi(ctx.Param("areaId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid area ID"})
+		return
+	}
+
+	req.Id = areaId
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := controller.areaService.Update(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to update area", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Area updated successfully"})
+}
+
+func (controller *AreaController) Delete(ctx *gin.Context) {
+	areaId, err := strconv.Atoi(ctx.Param("areaId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid area ID"})
+		return
+	}
+
+	if err := controller.areaService.Delete(ctx.Request.Context(), areaId); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to delete area", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Area deleted successfully"})
+}
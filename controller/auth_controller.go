@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"reports/config"
+	"reports/data/request"
+	"reports/helper"
+	"reports/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthController struct {
+	authService service.AuthService
+	config      *config.Config
+}
+
+func NewAuthController(authService service.AuthService, config *config.Config) *AuthController {
+	return &AuthController{authService: authService, config: config}
+}
+
+func (controller *AuthController) Login(ctx *gin.Context) {
+	var req request.LoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := controller.authService.Login(ctx.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in", "details": err.Error()})
+		return
+	}
+
+	ctx.SetCookie("token", token, controller.config.TokenMaxAge*60, "/", "", controller.config.TokenSecure, true)
+
+	ctx.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+func (controller *AuthController) Logout(ctx *gin.Context) {
+	ctx.SetCookie("token", "", -1, "/", "", controller.config.TokenSecure, true)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+func (controller *AuthController) Register(ctx *gin.Context) {
+	var req request.RegisterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := controller.authService.Register(ctx.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, service.ErrUsernameTaken) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to register user", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"user": user})
+}
+
+func (controller *AuthController) Me(ctx *gin.Context) {
+	user, ok := helper.CurrentUser(ctx.Request.Context())
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "You are not logged in"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"user": user})
+}
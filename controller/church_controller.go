@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"net/http"
+	"reports/data/request"
+	"reports/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChurchController struct {
+	churchService service.ChurchService
+}
+
+func NewChurchController(churchService service.ChurchService) *ChurchController {
+	return &ChurchController{churchService: churchService}
+}
+
+func (controller *ChurchController) Create(ctx *gin.Context) {
+	var req request.ChurchCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	church, err := controller.churchService.Create(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to create church", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"church": church})
+}
+
+func (controller *ChurchController) FindById(ctx *gin.Context) {
+	churchId, err := strconv.Atoi(ctx.Param("churchId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid church ID"})
+		return
+	}
+
+	church, err := controller.churchService.FindById(ctx.Request.Context(), churchId)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Church not found", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"church": church})
+}
+
+func (controller *ChurchController) FindAll(ctx *gin.Context) {
+	// Optional area filter, 0 lists everything
+	areaId := parseId(ctx.Query("area_id"))
+
+	churches, err := controller.churchService.FindAll(ctx.Request.Context(), areaId)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to fetch churches", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"churches": churches})
+}
+
+func (controller *ChurchController) Update(ctx *gin.Context) {
+	var req request.ChurchUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	churchId, err := strconv.Atoi(ctx.Param("churchId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid church ID"})
+		return
+	}
+
+	req.Id = churchId
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := controller.churchService.Update(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to update church", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Church updated successfully"})
+}
+
+func (controller *ChurchController) Delete(ctx *gin.Context) {
+	churchId, err := strconv.Atoi(ctx.Param("churchId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid church ID"})
+		return
+	}
+
+	if err := controller.churchService.Delete(ctx.Request.Context(), churchId); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to delete church", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Church deleted successfully"})
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"reports/service"
+)
+
+// errorStatus maps well-known service errors to their HTTP status code and
+// falls back to the status the handler would otherwise respond with.
+func errorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, service.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAlreadyExists), errors.Is(err, service.ErrInUse), errors.Is(err, service.ErrReportTaken),
+		errors.Is(err, service.ErrReportLocked), errors.Is(err, service.ErrInvalidTransition):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrChurchNotInArea), errors.Is(err, service.ErrAreaNotAssigned),
+		errors.Is(err, service.ErrReferenceNotFound), errors.Is(err, service.ErrInvalidImport),
+		errors.Is(err, service.ErrWorkerRequired), errors.Is(err, service.ErrUnknownActivity),
+		errors.Is(err, service.ErrInactiveActivity), errors.Is(err, service.ErrActivityKeyLocked):
+		return http.StatusBadRequest
+	}
+
+	return fallback
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"reports/model"
+	"strconv"
+	"time"
+)
+
+// parseId reads an optional id filter, treating anything invalid as unset.
+func parseId(value string) int {
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 0 {
+		return 0
+	}
+	return id
+}
+
+// parseDay reads a YYYY-MM-DD date as the start of that day in Manila,
+// where the reports are filed.
+func parseDay(value string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", value, model.Manila)
+}
+
+// currentMonth is the month it is now in Manila, the default of the month
+// filters.
+func currentMonth() model.Period {
+	return model.PeriodOf(time.Now().In(model.Manila))
+}
@@ -1,7 +1,10 @@
 package controller
 
 import (
-	"context"
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"reports/config"
 	"reports/data/request"
@@ -10,17 +13,31 @@ import (
 	"reports/utils"
 
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tealeg/xlsx"
 )
 
 type ReportController struct {
-	reportService service.ReportService
+	reportService   service.ReportService
+	activityService service.ActivityService
 }
 
-func NewReportController(reportService service.ReportService) *ReportController {
-	return &ReportController{reportService: reportService}
+func NewReportController(reportService service.ReportService, activityService service.ActivityService) *ReportController {
+	return &ReportController{reportService: reportService, activityService: activityService}
+}
+
+// catalog loads every activity, inactive ones included, for the exports and
+// templates. On failure it responds with an error and returns false.
+func (controller *ReportController) catalog(ctx *gin.Context) (model.ActivityCatalog, bool) {
+	catalog, err := controller.activityService.FindAll(ctx.Request.Context(), true)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to load activities", "details": err.Error()})
+		return nil, false
+	}
+	return catalog, true
 }
 
 func (controller *ReportController) Create(ctx *gin.Context) {
@@ -36,7 +53,12 @@ func (controller *ReportController) Create(ctx *gin.Context) {
 	}
 
 	if err := controller.reportService.Create(ctx.Request.Context(), &req); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create report", "details": err.Error()})
+		var conflict *service.ReportConflictError
+		if errors.As(err, &conflict) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "existing_report_id": conflict.ExistingId})
+			return
+		}
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to create report", "details": err.Error()})
 		return
 	}
 
@@ -50,9 +72,9 @@ func (controller *ReportController) FindById(ctx *gin.Context) {
 		return
 	}
 
-	report, err := controller.reportService.FindById(ctx, reportId)
+	report, err := controller.reportService.FindById(ctx.Request.Context(), reportId)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Report not found", "details": err.Error()})
+		ctx.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Report not found", "details": err.Error()})
 		return
 	}
 
@@ -60,24 +82,162 @@ func (controller *ReportController) FindById(ctx *gin.Context) {
 }
 
 func (controller *ReportController) FindAll(ctx *gin.Context) {
-	// Parse query parameters
-	query := model.SearchReportQuery{
-		MonthOf:    ctx.DefaultQuery("month_of", ""),
-		WorkerName: ctx.Query("worker_name"),
-		Page:       config.ParsePage(ctx.DefaultQuery("page", "1")),
-		PerPage:    config.ParsePerPage(ctx.DefaultQuery("per_page", "10")),
+	query, err := parseSearchReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	// Call the service layer to fetch data
-	result, err := controller.reportService.FindAll(ctx, &query)
+	result, err := controller.reportService.FindAll(ctx.Request.Context(), &query)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reports", "details": err.Error()})
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to fetch reports", "details": err.Error()})
 		return
 	}
 
 	// Return the JSON response
 	ctx.JSON(http.StatusOK, result)
 }
+
+func (controller *ReportController) Trash(ctx *gin.Context) {
+	query, err := parseSearchReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := controller.reportService.Trash(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to fetch deleted reports", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (controller *ReportController) Restore(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	if err := controller.reportService.Restore(ctx.Request.Context(), reportId); err != nil {
+		var conflict *service.ReportConflictError
+		if errors.As(err, &conflict) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "existing_report_id": conflict.ExistingId})
+			return
+		}
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to restore report", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Report restored successfully"})
+}
+
+// parseSearchReportQuery reads the list filters, paging, order and fields
+// shared by the report list endpoints from the query string.
+func parseSearchReportQuery(ctx *gin.Context) (model.SearchReportQuery, error) {
+	query, err := parseReportFilters(ctx)
+	if err != nil {
+		return query, err
+	}
+
+	query.Page = config.ParsePage(ctx.DefaultQuery("page", "1"))
+	query.PerPage = config.ParsePerPage(ctx.DefaultQuery("per_page", "10"))
+
+	if value := ctx.Query("after"); value != "" {
+		after, err := strconv.Atoi(value)
+		if err != nil || after <= 0 {
+			return query, errors.New("Invalid after cursor")
+		}
+		query.After = after
+	}
+
+	if value := ctx.Query("sort"); value != "" {
+		sort, err := model.ParseReportSort(value)
+		if err != nil {
+			return query, err
+		}
+		if len(sort) > 0 && query.After > 0 {
+			return query, errors.New("sort cannot be combined with after")
+		}
+		query.Sort = sort
+	}
+
+	if value := ctx.Query("fields"); value != "" {
+		fields, err := model.ParseReportFields(value)
+		if err != nil {
+			return query, err
+		}
+		query.Fields = fields
+	}
+
+	return query, nil
+}
+
+// parseReportFilters reads the filters that pick reports, shared by the
+// report list and the analytics endpoints.
+func parseReportFilters(ctx *gin.Context) (model.SearchReportQuery, error) {
+	query := model.SearchReportQuery{
+		MonthOf:    ctx.DefaultQuery("month_of", ""),
+		WorkerName: ctx.Query("worker_name"),
+		WorkerId:   parseId(ctx.Query("worker_id")),
+		AreaId:     parseId(ctx.Query("area_id")),
+		ChurchId:   parseId(ctx.Query("church_id")),
+		Status:     ctx.Query("status"),
+		Search:     strings.TrimSpace(ctx.Query("q")),
+	}
+
+	if query.Status != "" && !model.IsValidReportStatus(query.Status) {
+		return query, errors.New("Invalid report status")
+	}
+
+	for _, bound := range []struct {
+		param  string
+		target *model.Period
+	}{
+		{"month_from", &query.MonthFrom},
+		{"month_to", &query.MonthTo},
+	} {
+		if value := ctx.Query(bound.param); value != "" {
+			period, err := model.ParsePeriod(value)
+			if err != nil {
+				return query, fmt.Errorf("Invalid %s: %v", bound.param, err)
+			}
+			*bound.target = period
+		}
+	}
+
+	for _, bound := range []struct {
+		param  string
+		target *time.Time
+	}{
+		{"created_from", &query.CreatedFrom},
+		{"created_to", &query.CreatedTo},
+		{"updated_from", &query.UpdatedFrom},
+		{"updated_to", &query.UpdatedTo},
+	} {
+		if value := ctx.Query(bound.param); value != "" {
+			day, err := parseDay(value)
+			if err != nil {
+				return query, fmt.Errorf("Invalid %s, use YYYY-MM-DD", bound.param)
+			}
+			*bound.target = day
+		}
+	}
+
+	for _, value := range ctx.QueryArray("metric") {
+		metric, err := model.ParseMetricFilter(value)
+		if err != nil {
+			return query, err
+		}
+		query.Metrics = append(query.Metrics, metric)
+	}
+
+	return query, nil
+}
+
 func (controller *ReportController) Delete(ctx *gin.Context) {
 	reportId, err := strconv.Atoi(ctx.Param("reportId"))
 	if err != nil {
@@ -85,8 +245,8 @@ func (controller *ReportController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := controller.reportService.Delete(ctx, reportId); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete report", "details": err.Error()})
+	if err := controller.reportService.Delete(ctx.Request.Context(), reportId); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to delete report", "details": err.Error()})
 		return
 	}
 
@@ -116,8 +276,13 @@ func (controller *ReportController) Update(ctx *gin.Context) {
 	}
 
 	// Call service layer to update the report
-	if err := controller.reportService.Update(ctx, &req); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update report", "details": err.Error()})
+	if err := controller.reportService.Update(ctx.Request.Context(), &req); err != nil {
+		var conflict *service.ReportConflictError
+		if errors.As(err, &conflict) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "existing_report_id": conflict.ExistingId})
+			return
+		}
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to update report", "details": err.Error()})
 		return
 	}
 
@@ -125,6 +290,146 @@ func (controller *ReportController) Update(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Report updated successfully"})
 }
 
+func (controller *ReportController) Submit(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	if err := controller.reportService.Submit(ctx.Request.Context(), reportId); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to submit report", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Report submitted successfully"})
+}
+
+func (controller *ReportController) Approve(ctx *gin.Context) {
+	var req request.ReportApproveRequest
+
+	// The comment is optional, so an empty body is fine
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+	}
+
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	req.Id = reportId
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := controller.reportService.Approve(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to approve report", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Report approved successfully"})
+}
+
+func (controller *ReportController) Return(ctx *gin.Context) {
+	var req request.ReportReturnRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	req.Id = reportId
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := controller.reportService.Return(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to return report", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Report returned successfully"})
+}
+
+func (controller *ReportController) History(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	revisions, err := controller.reportService.History(ctx.Request.Context(), reportId)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to fetch report history", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+func (controller *ReportController) FindRevision(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	revisionNumber, err := strconv.Atoi(ctx.Param("revision"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision"})
+		return
+	}
+
+	revision, err := controller.reportService.FindRevision(ctx.Request.Context(), reportId, revisionNumber)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Revision not found", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"revision": revision})
+}
+
+func (controller *ReportController) RestoreRevision(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	revisionNumber, err := strconv.Atoi(ctx.Param("revision"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision"})
+		return
+	}
+
+	if err := controller.reportService.RestoreRevision(ctx.Request.Context(), reportId, revisionNumber); err != nil {
+		var conflict *service.ReportConflictError
+		if errors.As(err, &conflict) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "existing_report_id": conflict.ExistingId})
+			return
+		}
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to restore revision", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Revision restored successfully"})
+}
+
 func (controller *ReportController) ExportReport(ctx *gin.Context) {
 	reportId, err := strconv.Atoi(ctx.Param("reportId"))
 	if err != nil {
@@ -132,9 +437,32 @@ func (controller *ReportController) ExportReport(ctx *gin.Context) {
 		return
 	}
 
-	report, err := controller.reportService.FindById(context.Background(), reportId)
+	format := ctx.DefaultQuery("format", "xlsx")
+	if format != "xlsx" && format != "pdf" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid export format, use xlsx or pdf"})
+		return
+	}
+
+	report, err := controller.reportService.FindById(ctx.Request.Context(), reportId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	catalog, ok := controller.catalog(ctx)
+	if !ok {
+		return
+	}
+
+	if format == "pdf" {
+		var buffer bytes.Buffer
+		if err := utils.WriteReportPDF(&buffer, catalog, report); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create PDF", "details": err.Error()})
+			return
+		}
+
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=report_%d.pdf", report.Id))
+		ctx.Data(http.StatusOK, "application/pdf", buffer.Bytes())
 		return
 	}
 
@@ -147,7 +475,7 @@ func (controller *ReportController) ExportReport(ctx *gin.Context) {
 	}
 
 	// Add data to the Excel sheet
-	utils.AddReportToSheet(sheet, report)
+	utils.AddReportToSheet(sheet, catalog, report)
 
 	// Set the response headers
 	ctx.Header("Content-Description", "File Transfer")
@@ -159,3 +487,287 @@ func (controller *ReportController) ExportReport(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write Excel file"})
 	}
 }
+
+// Template downloads a blank report workbook for ?worker= and ?month=,
+// ready to be filled in offline and uploaded to ImportReports.
+func (controller *ReportController) Template(ctx *gin.Context) {
+	workerId := 0
+	if value := ctx.Query("worker"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid worker ID"})
+			return
+		}
+		workerId = id
+	}
+
+	month := ctx.Query("month")
+	if month != "" {
+		if _, err := model.ParsePeriod(month); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	report, err := controller.reportService.Template(ctx.Request.Context(), workerId, month)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to create template", "details": err.Error()})
+		return
+	}
+
+	catalog, ok := controller.catalog(ctx)
+	if !ok {
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := utils.WriteReportTemplate(&buffer, catalog, report); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create template", "details": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=report_template_%d_%s.xlsx", report.WorkerId, report.MonthOf))
+	ctx.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buffer.Bytes())
+}
+
+// ImportReports creates or updates reports from an uploaded workbook in the
+// layout of ExportReport. With dry_run=true the workbook is only checked.
+func (controller *ReportController) ImportReports(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Missing workbook in form field \"file\""})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	dryRun := ctx.Query("dry_run") == "true"
+
+	result, err := controller.reportService.ImportWorkbook(ctx.Request.Context(), file, dryRun)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to import reports", "details": err.Error()})
+		return
+	}
+
+	if !result.Valid && !dryRun {
+		ctx.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// ImportCSV inserts the reports of an uploaded CSV file. Invalid lines are
+// skipped and listed with their line number; dry_run=true only checks the
+// file and batch_size commits the valid lines in batches of that size, 500
+// by default and 0 for a single transaction. When saving fails midway the
+// error comes with the result of the batches already saved.
+func (controller *ReportController) ImportCSV(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Missing CSV file in form field \"file\""})
+		return
+	}
+
+	batchSize := service.DefaultImportBatchSize
+	if value := ctx.Query("batch_size"); value != "" {
+		batchSize, err = strconv.Atoi(value)
+		if err != nil || batchSize < 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid batch size"})
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	result, err := controller.reportService.ImportCSV(ctx.Request.Context(), file, ctx.Query("dry_run") == "true", batchSize)
+	if err != nil {
+		response := gin.H{"error": "Failed to import reports", "details": err.Error()}
+		if result != nil {
+			response["result"] = result
+		}
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), response)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// ExportReports downloads every report matching the list filters as one
+// workbook, with a summary sheet and a sheet per report.
+func (controller *ReportController) ExportReports(ctx *gin.Context) {
+	query, err := parseSearchReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	catalog, ok := controller.catalog(ctx)
+	if !ok {
+		return
+	}
+
+	workbook, err := utils.NewReportWorkbook(catalog)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create Excel workbook", "details": err.Error()})
+		return
+	}
+	defer workbook.Close()
+
+	err = controller.reportService.StreamReports(ctx.Request.Context(), &query, workbook.AddReport)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to export reports", "details": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Description", "File Transfer")
+	ctx.Header("Content-Disposition", "attachment; filename=reports.xlsx")
+	ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	if _, err := workbook.WriteTo(ctx.Writer); err != nil {
+		ctx.Error(err)
+	}
+}
+
+// ExportSummary downloads the consolidated sheet of a month: one row per
+// worker, a subtotal per area and a grand total. Filter with area_id to get
+// a single area. Like analytics it counts submitted and approved reports
+// unless status asks for others.
+func (controller *ReportController) ExportSummary(ctx *gin.Context) {
+	period, err := model.ParsePeriod(ctx.Query("month_of"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := model.SearchReportQuery{
+		MonthOf:       period.String(),
+		AreaId:        parseId(ctx.Query("area_id")),
+		Status:        ctx.Query("status"),
+		SubmittedOnly: true,
+	}
+	if query.Status != "" && !model.IsValidReportStatus(query.Status) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report status"})
+		return
+	}
+
+	catalog, ok := controller.catalog(ctx)
+	if !ok {
+		return
+	}
+
+	workbook, err := utils.NewSummaryWorkbook(period, catalog)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create Excel workbook", "details": err.Error()})
+		return
+	}
+	defer workbook.Close()
+
+	err = controller.reportService.StreamReports(ctx.Request.Context(), &query, workbook.AddReport)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to export summary", "details": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Description", "File Transfer")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=summary_%s.xlsx", period))
+	ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	if _, err := workbook.WriteTo(ctx.Writer); err != nil {
+		ctx.Error(err)
+	}
+}
+
+// ExportCSV streams every report matching the list filters as CSV, with the
+// weekly values flattened into one column per week.
+func (controller *ReportController) ExportCSV(ctx *gin.Context) {
+	catalog, ok := controller.catalog(ctx)
+	if !ok {
+		return
+	}
+
+	writer := utils.NewCSVReportWriter(ctx.Writer, catalog)
+	controller.streamExport(ctx, "text/csv; charset=utf-8", "reports.csv", writer.WriteHeader, writer.WriteReport, writer.WriteError)
+}
+
+// ExportNDJSON streams every report matching the list filters as one JSON
+// object per line, with the same flat fields as ExportCSV.
+func (controller *ReportController) ExportNDJSON(ctx *gin.Context) {
+	catalog, ok := controller.catalog(ctx)
+	if !ok {
+		return
+	}
+
+	writer := utils.NewNDJSONReportWriter(ctx.Writer, catalog)
+	controller.streamExport(ctx, "application/x-ndjson", "reports.ndjson", func() error { return nil }, writer.WriteReport, writer.WriteError)
+}
+
+// streamExport writes reports to the response as they are read from the
+// database. The response starts with the first report, so errors raised
+// before it (bad filters, permissions) still get a JSON error response.
+// A failure after that cuts the download short: writeError marks the end
+// of the file and the X-Export-Error trailer carries the error.
+func (controller *ReportController) streamExport(ctx *gin.Context, contentType, fileName string, writeHeader func() error, writeReport func(report *model.Report) error, writeError func(err error) error) {
+	query, err := parseSearchReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	started := false
+	start := func() error {
+		started = true
+		ctx.Header("Content-Disposition", "attachment; filename="+fileName)
+		ctx.Header("Content-Type", contentType)
+		ctx.Header("Trailer", "X-Export-Error")
+		ctx.Status(http.StatusOK)
+		return writeHeader()
+	}
+
+	count := 0
+	err = controller.reportService.StreamReports(ctx.Request.Context(), &query, func(report *model.Report) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		if err := writeReport(report); err != nil {
+			return err
+		}
+
+		count++
+		if count%50 == 0 {
+			ctx.Writer.Flush()
+		}
+		return nil
+	})
+
+	if err == nil && !started {
+		err = start()
+	}
+
+	if err != nil {
+		if !started {
+			ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to export reports", "details": err.Error()})
+			return
+		}
+		log.Printf("report export %s failed after %d reports: %v", fileName, count, err)
+		if writeErr := writeError(err); writeErr != nil {
+			log.Printf("cannot mark report export %s as incomplete: %v", fileName, writeErr)
+		}
+		ctx.Writer.Header().Set("X-Export-Error", err.Error())
+		ctx.Error(err)
+		ctx.Abort()
+	}
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"net/http"
+	"reports/data/request"
+	"reports/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkerController struct {
+	workerService service.WorkerService
+}
+
+func NewWorkerController(workerService service.WorkerService) *WorkerController {
+	return &WorkerController{workerService: workerService}
+}
+
+func (controller *WorkerController) Create(ctx *gin.Context) {
+	var req request.WorkerCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	worker, err := controller.workerService.Create(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to create worker", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"worker": worker})
+}
+
+func (controller *WorkerController) FindById(ctx *gin.Context) {
+	workerId, err := strconv.Atoi(ctx.Param("workerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid worker ID"})
+		return
+	}
+
+	worker, err := controller.workerService.FindById(ctx.Request.Context(), workerId)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusNotFound), gin.H{"error": "Worker not found", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"worker": worker})
+}
+
+func (controller *WorkerController) FindAll(ctx *gin.Context) {
+	// Optional area filter, 0 lists everything
+	areaId := parseId(ctx.Query("area_id"))
+
+	workers, err := controller.workerService.FindAll(ctx.Request.Context(), areaId)
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to fetch workers", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"workers": workers})
+}
+
+func (controller *WorkerController) Update(ctx *gin.Context) {
+	var req request.WorkerUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	workerId, err := strconv.Atoi(ctx.Param("workerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid worker ID"})
+		return
+	}
+
+	req.Id = workerId
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := controller.workerService.Update(ctx.Request.Context(), &req); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to update worker", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Worker updated successfully"})
+}
+
+func (controller *WorkerController) Delete(ctx *gin.Context) {
+	workerId, err := strconv.Atoi(ctx.Param("workerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid worker ID"})
+		return
+	}
+
+	if err := controller.workerService.Delete(ctx.Request.Context(), workerId); err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": "Failed to delete worker", "details": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Worker deleted successfully"})
+}
@@ -0,0 +1,32 @@
+package request
+
+import (
+	"errors"
+	"reports/model"
+	"strings"
+)
+
+type ActivityCreateRequest struct {
+	Key          string `json:"key" validate:"required"`
+	Label        string `json:"label" validate:"required"`
+	Category     string `json:"category" validate:"required"`
+	DisplayOrder int    `json:"display_order"`
+	// Active defaults to true when left out.
+	Active *bool `json:"active"`
+}
+
+func (request *ActivityCreateRequest) Validate() error {
+	if !model.IsValidActivityKey(request.Key) {
+		return errors.New("key must start with a lower case letter and hold only lower case letters, digits and underscores")
+	}
+
+	if len(strings.TrimSpace(request.Label)) == 0 {
+		return errors.New("label must not be empty")
+	}
+
+	if !model.IsValidActivityCategory(request.Category) {
+		return errors.New("category must be attendance or personal_ministry")
+	}
+
+	return nil
+}
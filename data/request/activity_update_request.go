@@ -0,0 +1,35 @@
+package request
+
+import (
+	"errors"
+	"reports/model"
+	"strings"
+)
+
+// ActivityUpdateRequest changes how an activity is shown. Key may be sent
+// back as read, but it cannot be changed.
+type ActivityUpdateRequest struct {
+	Id           int    `json:"id" validate:"required"`
+	Key          string `json:"key"`
+	Label        string `json:"label" validate:"required"`
+	Category     string `json:"category" validate:"required"`
+	DisplayOrder int    `json:"display_order"`
+	// Active is left as it is when left out.
+	Active *bool `json:"active"`
+}
+
+func (request *ActivityUpdateRequest) Validate() error {
+	if request.Id <= 0 {
+		return errors.New("id is invalid")
+	}
+
+	if len(strings.TrimSpace(request.Label)) == 0 {
+		return errors.New("label must not be empty")
+	}
+
+	if !model.IsValidActivityCategory(request.Category) {
+		return errors.New("category must be attendance or personal_ministry")
+	}
+
+	return nil
+}
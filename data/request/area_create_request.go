@@ -0,0 +1,18 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+type AreaCreateRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func (request *AreaCreateRequest) Validate() error {
+	if len(strings.TrimSpace(request.Name)) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+type AreaUpdateRequest struct {
+	Id   int    `json:"id" validate:"required"`
+	Name string `json:"name" validate:"required"`
+}
+
+func (request *AreaUpdateRequest) Validate() error {
+	if request.Id <= 0 {
+		return errors.New("id is invalid")
+	}
+
+	if len(strings.TrimSpace(request.Name)) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	return nil
+}
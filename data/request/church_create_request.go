@@ -0,0 +1,23 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+type ChurchCreateRequest struct {
+	Name   string `json:"name" validate:"required"`
+	AreaId int    `json:"area_id" validate:"required"`
+}
+
+func (request *ChurchCreateRequest) Validate() error {
+	if len(strings.TrimSpace(request.Name)) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	if request.AreaId <= 0 {
+		return errors.New("area must not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+type ChurchUpdateRequest struct {
+	Id     int    `json:"id" validate:"required"`
+	Name   string `json:"name" validate:"required"`
+	AreaId int    `json:"area_id" validate:"required"`
+}
+
+func (request *ChurchUpdateRequest) Validate() error {
+	if request.Id <= 0 {
+		return errors.New("id is invalid")
+	}
+
+	if len(strings.TrimSpace(request.Name)) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	if request.AreaId <= 0 {
+		return errors.New("area must not be empty")
+	}
+
+	return nil
+}
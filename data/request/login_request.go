@@ -0,0 +1,20 @@
+package request
+
+import "errors"
+
+type LoginRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+func (request *LoginRequest) Validate() error {
+	if len(request.Username) == 0 {
+		return errors.New("username must not be empty")
+	}
+
+	if len(request.Password) == 0 {
+		return errors.New("password must not be empty")
+	}
+
+	return nil
+}
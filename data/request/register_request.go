@@ -0,0 +1,38 @@
+package request
+
+import (
+	"errors"
+	"reports/model"
+)
+
+type RegisterRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+	Role     string `json:"role" validate:"required"`
+	WorkerId int    `json:"worker_id,omitempty"`
+	AreaId   int    `json:"area_id,omitempty"`
+}
+
+func (request *RegisterRequest) Validate() error {
+	if len(request.Username) == 0 {
+		return errors.New("username must not be empty")
+	}
+
+	if len(request.Password) < 8 {
+		return errors.New("password must be at least 8 characters")
+	}
+
+	if !model.IsValidRole(request.Role) {
+		return errors.New("role must be one of worker, area_supervisor or national_admin")
+	}
+
+	if request.Role == model.RoleWorker && request.WorkerId <= 0 {
+		return errors.New("worker must not be empty for a worker")
+	}
+
+	if request.Role == model.RoleAreaSupervisor && request.AreaId <= 0 {
+		return errors.New("area of assignment must not be empty for an area supervisor")
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"fmt"
+	"reports/model"
+)
+
+// validateActivities checks the shape of the weekly values. Whether the
+// activities exist in the catalog is up to the service.
+func validateActivities(activities model.ActivityValues) error {
+	for key, values := range activities {
+		if !model.IsValidActivityKey(key) {
+			return fmt.Errorf("invalid activity %q", key)
+		}
+
+		if len(values) > model.ReportWeeks {
+			return fmt.Errorf("%s has %d weeks, a month has at most %d", key, len(values), model.ReportWeeks)
+		}
+
+		for week, value := range values {
+			if value < 0 {
+				return fmt.Errorf("%s week %d must not be negative", key, week+1)
+			}
+		}
+	}
+
+	return nil
+}
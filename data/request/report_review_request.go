@@ -0,0 +1,36 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+type ReportApproveRequest struct {
+	Id      int    `json:"id" validate:"required"`
+	Comment string `json:"comment,omitempty"`
+}
+
+func (request *ReportApproveRequest) Validate() error {
+	if request.Id <= 0 {
+		return errors.New("id is invalid")
+	}
+
+	return nil
+}
+
+type ReportReturnRequest struct {
+	Id      int    `json:"id" validate:"required"`
+	Comment string `json:"comment" validate:"required"`
+}
+
+func (request *ReportReturnRequest) Validate() error {
+	if request.Id <= 0 {
+		return errors.New("id is invalid")
+	}
+
+	if len(strings.TrimSpace(request.Comment)) == 0 {
+		return errors.New("comment must not be empty when returning a report")
+	}
+
+	return nil
+}
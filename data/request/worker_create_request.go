@@ -0,0 +1,27 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+type WorkerCreateRequest struct {
+	Name     string `json:"name" validate:"required"`
+	AreaId   int    `json:"area_id,omitempty"`
+	ChurchId int    `json:"church_id,omitempty"`
+
+	// Active is true when left out; inactive workers are off the roster.
+	Active *bool `json:"active,omitempty"`
+}
+
+func (request *WorkerCreateRequest) Validate() error {
+	if len(strings.TrimSpace(request.Name)) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	if request.ChurchId > 0 && request.AreaId <= 0 {
+		return errors.New("area must not be empty when a church is set")
+	}
+
+	return nil
+}
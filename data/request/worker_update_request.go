@@ -0,0 +1,32 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+type WorkerUpdateRequest struct {
+	Id       int    `json:"id" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	AreaId   int    `json:"area_id,omitempty"`
+	ChurchId int    `json:"church_id,omitempty"`
+
+	// Active is left as it is when left out.
+	Active *bool `json:"active,omitempty"`
+}
+
+func (request *WorkerUpdateRequest) Validate() error {
+	if request.Id <= 0 {
+		return errors.New("id is invalid")
+	}
+
+	if len(strings.TrimSpace(request.Name)) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	if request.ChurchId > 0 && request.AreaId <= 0 {
+		return errors.New("area must not be empty when a church is set")
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package helper
+
+import (
+	"context"
+	"reports/model"
+)
+
+type contextKey string
+
+const currentUserKey contextKey = "currentUser"
+
+// WithCurrentUser returns a copy of ctx carrying the authenticated user.
+func WithCurrentUser(ctx context.Context, user *model.User) context.Context {
+	return context.WithValue(ctx, currentUserKey, user)
+}
+
+// CurrentUser returns the authenticated user stored in ctx, if any.
+func CurrentUser(ctx context.Context) (*model.User, bool) {
+	user, ok := ctx.Value(currentUserKey).(*model.User)
+	return user, ok && user != nil
+}
@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 )
@@ -31,3 +32,42 @@ func CommitOrRollback(tx *sql.Tx) {
 		}
 	}()
 }
+
+// InTransaction runs fn in tx and commits when fn succeeds. An error or a
+// panic rolls everything fn wrote back, so callers can group writes that
+// must be stored together.
+func InTransaction(tx *sql.Tx, fn func(tx *sql.Tx) error) error {
+	defer func() {
+		if err := recover(); err != nil {
+			tx.Rollback()
+			panic(err)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			fmt.Printf("Failed to rollback transaction: %v\n", rollbackErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// InSavepoint runs fn in a savepoint of tx. When fn fails only its writes
+// are rolled back and tx can go on, so one bad row does not abort a batch.
+func InSavepoint(ctx context.Context, tx *sql.Tx, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT row_write"); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT row_write"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT row_write")
+	return err
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reports/helper"
+	"reports/repository"
+	"reports/service"
+)
+
+const importUsage = "usage: import-csv [-dry-run] [-batch-size N] [-user USERNAME] FILE"
+
+// runImportCommand runs "import-csv", loading a CSV file of reports as the
+// given user, the bootstrap admin unless -user is set.
+func runImportCommand(ctx context.Context, reportService service.ReportService, userRepository repository.UserRepository, adminUsername string, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("import-csv", flag.ContinueOnError)
+	flags.SetOutput(out)
+	dryRun := flags.Bool("dry-run", false, "check the file without saving")
+	batchSize := flags.Int("batch-size", service.DefaultImportBatchSize, "reports per transaction, 0 for one transaction")
+	username := flags.String("user", adminUsername, "user the reports are imported as")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New(importUsage)
+	}
+
+	user, err := userRepository.FindByUsername(ctx, *username)
+	if err != nil {
+		return fmt.Errorf("cannot find user %q: %w", *username, err)
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	result, err := reportService.ImportCSV(helper.WithCurrentUser(ctx, user), file, *dryRun, *batchSize)
+	if result == nil {
+		return err
+	}
+
+	for _, importError := range result.Errors {
+		if importError.Field != "" {
+			fmt.Fprintf(out, "line %d: %s: %s\n", importError.Line, importError.Field, importError.Message)
+		} else {
+			fmt.Fprintf(out, "line %d: %s\n", importError.Line, importError.Message)
+		}
+	}
+
+	if result.DryRun {
+		fmt.Fprintf(out, "checked %d report(s), %d error(s)\n", len(result.Reports), len(result.Errors))
+	} else {
+		fmt.Fprintf(out, "imported %d of %d report(s)\n", result.Created, len(result.Reports))
+	}
+
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return fmt.Errorf("%d error(s) found", len(result.Errors))
+	}
+
+	return nil
+}
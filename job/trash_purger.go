@@ -0,0 +1,48 @@
+package job
+
+import (
+	"context"
+	"log"
+	"reports/repository"
+	"time"
+)
+
+// TrashPurger permanently removes reports that have been in the trash for
+// longer than Retention, checking every Interval.
+type TrashPurger struct {
+	reportRepository repository.ReportRepository
+	Retention        time.Duration
+	Interval         time.Duration
+}
+
+func NewTrashPurger(reportRepository repository.ReportRepository, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{reportRepository: reportRepository, Retention: retention, Interval: interval}
+}
+
+// Run purges once right away and then on every tick until ctx is done.
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) purge(ctx context.Context) {
+	purged, err := p.reportRepository.Purge(ctx, time.Now().UTC().Add(-p.Retention))
+	if err != nil {
+		log.Println("cannot purge trash: ", err)
+		return
+	}
+
+	if purged > 0 {
+		log.Printf("purged %d reports from the trash", purged)
+	}
+}
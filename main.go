@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os"
 	"reports/config"
 	"reports/controller"
+	"reports/job"
+	"reports/middleware"
+	"reports/migration"
+	"reports/model"
 	"reports/repository"
 	"reports/router"
 	"reports/service"
+	"time"
 )
 
 func main() {
@@ -20,16 +27,96 @@ func main() {
 	// Database
 	db := config.ConnectionDB(&loadConfig)
 
+	// Migrations
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		log.Fatal("cannot load migrations: ", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migration.RunCommand(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if loadConfig.MigrateOnStartup {
+		if _, err := migrator.Up(context.Background()); err != nil {
+			log.Fatal("cannot run migrations: ", err)
+		}
+	}
+
 	// Repository
 	reportRepository := repository.NewReportRepository(db)
+	reportRevisionRepository := repository.NewReportRevisionRepository(db)
+	userRepository := repository.NewUserRepository(db)
+	areaRepository := repository.NewAreaRepository(db)
+	churchRepository := repository.NewChurchRepository(db)
+	workerRepository := repository.NewWorkerRepository(db)
+	activityRepository := repository.NewActivityRepository(db)
+	analyticsRepository := repository.NewAnalyticsRepository(db)
+
+	averageRounding, err := model.ParseAverageRounding(loadConfig.AverageRounding, loadConfig.AveragePrecision)
+	if err != nil {
+		log.Fatal("invalid average rounding: ", err)
+	}
+
+	if err := model.CheckDeadlineDay(loadConfig.ReportDeadlineDay); err != nil {
+		log.Fatal(err)
+	}
 
 	// Service
-	reportService := service.NewReportServiceImpl(reportRepository)
+	reportService := service.NewReportServiceImpl(reportRepository, reportRevisionRepository, workerRepository, areaRepository, churchRepository, activityRepository, averageRounding)
+	authService := service.NewAuthServiceImpl(userRepository, &loadConfig)
+	areaService := service.NewAreaServiceImpl(areaRepository)
+	churchService := service.NewChurchServiceImpl(churchRepository)
+	workerService := service.NewWorkerServiceImpl(workerRepository, churchRepository)
+	activityService := service.NewActivityServiceImpl(activityRepository)
+	analyticsService := service.NewAnalyticsServiceImpl(analyticsRepository, activityRepository, averageRounding, loadConfig.ReportDeadlineDay)
+
+	err = authService.EnsureAdmin(context.Background(), loadConfig.AdminUsername, loadConfig.AdminPassword)
+	if err != nil {
+		log.Fatal("cannot create admin user: ", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "import-csv" {
+		if err := runImportCommand(context.Background(), reportService, userRepository, loadConfig.AdminUsername, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "recompute-stats" {
+		if err := runStatsCommand(context.Background(), reportService, userRepository, loadConfig.AdminUsername, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Jobs
+	if loadConfig.TrashRetentionDays > 0 {
+		interval := loadConfig.TrashPurgeInterval
+		if interval <= 0 {
+			interval = 24 * time.Hour
+		}
+
+		retention := time.Duration(loadConfig.TrashRetentionDays) * 24 * time.Hour
+		go job.NewTrashPurger(reportRepository, retention, interval).Run(context.Background())
+	}
 
 	// Controller
-	reportController := controller.NewReportController(reportService)
+	reportController := controller.NewReportController(reportService, activityService)
+	authController := controller.NewAuthController(authService, &loadConfig)
+	areaController := controller.NewAreaController(areaService)
+	churchController := controller.NewChurchController(churchService)
+	workerController := controller.NewWorkerController(workerService)
+	activityController := controller.NewActivityController(activityService)
+	analyticsController := controller.NewAnalyticsController(analyticsService)
+
+	// Middleware
+	authMiddleware := middleware.DeserializeUser(authService, &loadConfig)
 
-	router := router.NewRouter(reportController)
+	router := router.NewRouter(authMiddleware, authController, areaController, churchController, workerController, reportController, activityController, analyticsController)
 
 	server := &http.Server{
 		Addr:    ":8080",
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"reports/config"
+	"reports/helper"
+	"reports/service"
+	"reports/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeserializeUser authenticates the request from a bearer token or the
+// "token" cookie and stores the user in the request context.
+func DeserializeUser(authService service.AuthService, config *config.Config) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var token string
+
+		fields := strings.Fields(ctx.GetHeader("Authorization"))
+		if len(fields) == 2 && strings.EqualFold(fields[0], "Bearer") {
+			token = fields[1]
+		} else if cookie, err := ctx.Cookie("token"); err == nil {
+			token = cookie
+		}
+
+		if token == "" {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "You are not logged in"})
+			return
+		}
+
+		sub, err := utils.ValidateToken(token, config.TokenSecret)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		userId, err := strconv.Atoi(sub)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		user, err := authService.FindUserById(ctx.Request.Context(), userId)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "The user belonging to this token no longer exists"})
+			return
+		}
+
+		ctx.Set("currentUser", user)
+		ctx.Request = ctx.Request.WithContext(helper.WithCurrentUser(ctx.Request.Context(), user))
+		ctx.Next()
+	}
+}
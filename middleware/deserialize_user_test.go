@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"reports/config"
+	"reports/helper"
+	"reports/model"
+	"reports/service"
+	"reports/utils"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type stubAuthService struct {
+	service.AuthService
+	users map[int]*model.User
+}
+
+func (s stubAuthService) FindUserById(ctx context.Context, userId int) (*model.User, error) {
+	if user, ok := s.users[userId]; ok {
+		return user, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestDeserializeUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{TokenSecret: "test-secret"}
+	authService := stubAuthService{users: map[int]*model.User{7: {Id: 7, Username: "admin", Role: model.RoleNationalAdmin}}}
+
+	valid, _ := utils.GenerateToken(time.Hour, "7", cfg.TokenSecret)
+	expired, _ := utils.GenerateToken(-time.Minute, "7", cfg.TokenSecret)
+	otherSecret, _ := utils.GenerateToken(time.Hour, "7", "other-secret")
+	notAnId, _ := utils.GenerateToken(time.Hour, "admin", cfg.TokenSecret)
+	goneUser, _ := utils.GenerateToken(time.Hour, "8", cfg.TokenSecret)
+
+	tests := []struct {
+		name   string
+		header string
+		cookie string
+		want   int
+	}{
+		{"bearer header", "Bearer " + valid, "", http.StatusOK},
+		{"lowercase scheme", "bearer " + valid, "", http.StatusOK},
+		{"cookie", "", valid, http.StatusOK},
+		{"header wins over cookie", "Bearer " + valid, expired, http.StatusOK},
+		{"no token", "", "", http.StatusUnauthorized},
+		{"other scheme", "Basic " + valid, "", http.StatusUnauthorized},
+		{"expired", "Bearer " + expired, "", http.StatusUnauthorized},
+		{"bad cookie", "", "garbage", http.StatusUnauthorized},
+		{"other secret", "Bearer " + otherSecret, "", http.StatusUnauthorized},
+		{"subject not an id", "Bearer " + notAnId, "", http.StatusUnauthorized},
+		{"user gone", "Bearer " + goneUser, "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", DeserializeUser(authService, cfg), func(ctx *gin.Context) {
+				user, ok := helper.CurrentUser(ctx.Request.Context())
+				if !ok || user.Id != 7 {
+					t.Errorf("current user = %v, %v, want user 7", user, ok)
+				}
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "token", Value: tt.cookie})
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
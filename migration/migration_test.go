@@ -0,0 +1,20 @@
+package migration
+
+import "testing"
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		t.Fatalf("loadMigrations() error = %v", err)
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Fatalf("migration %d has version %d, versions must be contiguous from 1", i, migration.Version)
+		}
+	}
+
+	if migrations[0].Name != "create_reports_table" {
+		t.Fatalf("first migration = %q, want create_reports_table", migrations[0].Name)
+	}
+}
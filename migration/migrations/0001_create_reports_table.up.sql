@@ -1,5 +1,5 @@
 
-CREATE TABLE reports (
+CREATE TABLE IF NOT EXISTS reports (
     id SERIAL PRIMARY KEY,
     month_of VARCHAR(100) NOT NULL,
     worker_name VARCHAR(100) NOT NULL,
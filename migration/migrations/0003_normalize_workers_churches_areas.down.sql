@@ -0,0 +1,46 @@
+-- Restores the free-text worker, area and church columns from the
+-- normalized tables and drops them.
+
+ALTER TABLE users
+    ADD COLUMN worker_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN area_of_assignment VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE users u
+SET worker_name = w.name
+FROM workers w
+WHERE w.id = u.worker_id;
+
+UPDATE users u
+SET area_of_assignment = a.name
+FROM areas a
+WHERE a.id = u.area_id;
+
+ALTER TABLE users
+    DROP COLUMN worker_id,
+    DROP COLUMN area_id;
+
+ALTER TABLE reports
+    ADD COLUMN worker_name VARCHAR(100),
+    ADD COLUMN area_of_assignment VARCHAR(100),
+    ADD COLUMN name_of_church VARCHAR(100);
+
+UPDATE reports r
+SET worker_name = w.name,
+    area_of_assignment = a.name,
+    name_of_church = c.name
+FROM workers w, areas a, churches c
+WHERE w.id = r.worker_id
+    AND a.id = r.area_id
+    AND c.id = r.church_id;
+
+ALTER TABLE reports
+    ALTER COLUMN worker_name SET NOT NULL,
+    ALTER COLUMN area_of_assignment SET NOT NULL,
+    ALTER COLUMN name_of_church SET NOT NULL,
+    DROP COLUMN worker_id,
+    DROP COLUMN area_id,
+    DROP COLUMN church_id;
+
+DROP TABLE workers;
+DROP TABLE churches;
+DROP TABLE areas;
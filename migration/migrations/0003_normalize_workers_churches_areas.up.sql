@@ -0,0 +1,146 @@
+-- Moves the free-text worker, area and church columns on reports and users
+-- into their own tables. Values that only differ in case or whitespace
+-- ("Juan Dela Cruz" and "juan  dela cruz ") collapse into a single row whose
+-- name is the most frequently used spelling.
+
+CREATE OR REPLACE FUNCTION pg_temp.normalize_name(value TEXT) RETURNS TEXT AS $$
+    SELECT REGEXP_REPLACE(BTRIM(value), '\s+', ' ', 'g')
+$$ LANGUAGE SQL IMMUTABLE;
+
+CREATE TABLE areas (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX areas_name_key ON areas (LOWER(name));
+
+CREATE TABLE churches (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    area_id INTEGER NOT NULL REFERENCES areas (id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX churches_area_name_key ON churches (area_id, LOWER(name));
+
+CREATE TABLE workers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    area_id INTEGER REFERENCES areas (id),
+    church_id INTEGER REFERENCES churches (id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX workers_name_key ON workers (LOWER(name));
+
+-- Areas
+INSERT INTO areas (name)
+SELECT DISTINCT ON (LOWER(name)) name
+FROM (
+    SELECT pg_temp.normalize_name(area_of_assignment) AS name, COUNT(*) AS uses
+    FROM (
+        SELECT area_of_assignment FROM reports
+        UNION ALL
+        SELECT area_of_assignment FROM users
+    ) source
+    WHERE pg_temp.normalize_name(area_of_assignment) <> ''
+    GROUP BY 1
+) spellings
+ORDER BY LOWER(name), uses DESC, name;
+
+-- Churches, unique per area
+INSERT INTO churches (name, area_id)
+SELECT DISTINCT ON (a.id, LOWER(spellings.name)) spellings.name, a.id
+FROM (
+    SELECT
+        pg_temp.normalize_name(name_of_church) AS name,
+        LOWER(pg_temp.normalize_name(area_of_assignment)) AS area_key,
+        COUNT(*) AS uses
+    FROM reports
+    WHERE pg_temp.normalize_name(name_of_church) <> ''
+    GROUP BY 1, 2
+) spellings
+JOIN areas a ON LOWER(a.name) = spellings.area_key
+ORDER BY a.id, LOWER(spellings.name), spellings.uses DESC, spellings.name;
+
+-- Workers, assigned to the area and church of their latest report
+INSERT INTO workers (name)
+SELECT DISTINCT ON (LOWER(name)) name
+FROM (
+    SELECT pg_temp.normalize_name(worker_name) AS name, COUNT(*) AS uses
+    FROM (
+        SELECT worker_name FROM reports
+        UNION ALL
+        SELECT worker_name FROM users
+    ) source
+    WHERE pg_temp.normalize_name(worker_name) <> ''
+    GROUP BY 1
+) spellings
+ORDER BY LOWER(name), uses DESC, name;
+
+-- Reports
+ALTER TABLE reports
+    ADD COLUMN worker_id INTEGER REFERENCES workers (id),
+    ADD COLUMN area_id INTEGER REFERENCES areas (id),
+    ADD COLUMN church_id INTEGER REFERENCES churches (id);
+
+UPDATE reports r
+SET area_id = a.id
+FROM areas a
+WHERE LOWER(a.name) = LOWER(pg_temp.normalize_name(r.area_of_assignment));
+
+UPDATE reports r
+SET church_id = c.id
+FROM churches c
+WHERE c.area_id = r.area_id
+    AND LOWER(c.name) = LOWER(pg_temp.normalize_name(r.name_of_church));
+
+UPDATE reports r
+SET worker_id = w.id
+FROM workers w
+WHERE LOWER(w.name) = LOWER(pg_temp.normalize_name(r.worker_name));
+
+UPDATE workers w
+SET area_id = latest.area_id,
+    church_id = latest.church_id
+FROM (
+    SELECT DISTINCT ON (worker_id) worker_id, area_id, church_id
+    FROM reports
+    ORDER BY worker_id, created_at DESC, id DESC
+) latest
+WHERE latest.worker_id = w.id;
+
+ALTER TABLE reports
+    ALTER COLUMN worker_id SET NOT NULL,
+    ALTER COLUMN area_id SET NOT NULL,
+    ALTER COLUMN church_id SET NOT NULL,
+    DROP COLUMN worker_name,
+    DROP COLUMN area_of_assignment,
+    DROP COLUMN name_of_church;
+
+CREATE INDEX reports_worker_id_idx ON reports (worker_id);
+CREATE INDEX reports_area_id_idx ON reports (area_id);
+CREATE INDEX reports_church_id_idx ON reports (church_id);
+
+-- Users
+ALTER TABLE users
+    ADD COLUMN worker_id INTEGER REFERENCES workers (id),
+    ADD COLUMN area_id INTEGER REFERENCES areas (id);
+
+UPDATE users u
+SET worker_id = w.id
+FROM workers w
+WHERE LOWER(w.name) = LOWER(pg_temp.normalize_name(u.worker_name));
+
+UPDATE users u
+SET area_id = a.id
+FROM areas a
+WHERE LOWER(a.name) = LOWER(pg_temp.normalize_name(u.area_of_assignment));
+
+ALTER TABLE users
+    DROP COLUMN worker_name,
+    DROP COLUMN area_of_assignment;
@@ -0,0 +1,75 @@
+ALTER TABLE reports DROP CONSTRAINT reports_worker_month_key;
+
+ALTER TABLE reports
+    ALTER COLUMN month_of TYPE VARCHAR(100) USING TO_CHAR(month_of, 'FMMonth YYYY');
+
+INSERT INTO reports (
+    id,
+    month_of,
+    worker_id,
+    area_id,
+    church_id,
+    worship_service,
+    sunday_school,
+    prayer_meetings,
+    bible_studies,
+    mens_fellowships,
+    womens_fellowships,
+    youth_fellowships,
+    child_fellowships,
+    outreach,
+    training_or_seminars,
+    leadership_conferences,
+    leadership_training,
+    others,
+    family_days,
+    tithes_and_offerings,
+    home_visited,
+    bible_study_or_group_led,
+    sermon_or_message_preached,
+    person_newly_contacted,
+    person_followed_up,
+    person_led_to_christ,
+    names,
+    narrative_report,
+    challenges_and_problem_encountered,
+    prayer_request,
+    created_at,
+    updated_at
+)
+SELECT
+    id,
+    month_of,
+    worker_id,
+    area_id,
+    church_id,
+    worship_service,
+    sunday_school,
+    prayer_meetings,
+    bible_studies,
+    mens_fellowships,
+    womens_fellowships,
+    youth_fellowships,
+    child_fellowships,
+    outreach,
+    training_or_seminars,
+    leadership_conferences,
+    leadership_training,
+    others,
+    family_days,
+    tithes_and_offerings,
+    home_visited,
+    bible_study_or_group_led,
+    sermon_or_message_preached,
+    person_newly_contacted,
+    person_followed_up,
+    person_led_to_christ,
+    names,
+    narrative_report,
+    challenges_and_problem_encountered,
+    prayer_request,
+    created_at,
+    updated_at
+FROM report_duplicates;
+
+DROP TABLE report_duplicates;
@@ -0,0 +1,73 @@
+-- Stores month_of as the first day of the month and allows a single report
+-- per worker per month. Older duplicates are moved to report_duplicates for
+-- manual review instead of being discarded.
+
+CREATE OR REPLACE FUNCTION pg_temp.parse_period(value TEXT) RETURNS DATE AS $$
+DECLARE
+    cleaned TEXT := LOWER(REGEXP_REPLACE(BTRIM(value), '[\s,.]+', ' ', 'g'));
+    parts TEXT[];
+    month_names TEXT[] := ARRAY['january', 'february', 'march', 'april', 'may', 'june', 'july', 'august', 'september', 'october', 'november', 'december'];
+    i INTEGER;
+BEGIN
+    IF cleaned ~ '^\d{4}[-/]\d{1,2}$' THEN
+        parts := REGEXP_SPLIT_TO_ARRAY(cleaned, '[-/]');
+        RETURN MAKE_DATE(parts[1]::INTEGER, parts[2]::INTEGER, 1);
+    END IF;
+
+    IF cleaned ~ '^\d{1,2}[-/]\d{4}$' THEN
+        parts := REGEXP_SPLIT_TO_ARRAY(cleaned, '[-/]');
+        RETURN MAKE_DATE(parts[2]::INTEGER, parts[1]::INTEGER, 1);
+    END IF;
+
+    IF cleaned ~ '^[a-z]+ ?\d{4}$' THEN
+        parts := REGEXP_MATCH(cleaned, '^([a-z]+) ?(\d{4})$');
+        FOR i IN 1..12 LOOP
+            IF month_names[i] = parts[1] OR (LENGTH(parts[1]) >= 3 AND month_names[i] LIKE parts[1] || '%') THEN
+                RETURN MAKE_DATE(parts[2]::INTEGER, i, 1);
+            END IF;
+        END LOOP;
+    END IF;
+
+    RETURN NULL;
+EXCEPTION WHEN OTHERS THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+ALTER TABLE reports ADD COLUMN period DATE;
+
+UPDATE reports SET period = pg_temp.parse_period(month_of);
+
+DO $$
+DECLARE
+    unparsed TEXT;
+BEGIN
+    SELECT STRING_AGG(id || ' (' || month_of || ')', ', ' ORDER BY id)
+    INTO unparsed
+    FROM reports
+    WHERE period IS NULL;
+
+    IF unparsed IS NOT NULL THEN
+        RAISE EXCEPTION 'reports with an unrecognized month_of, fix them and migrate again: %', unparsed;
+    END IF;
+END $$;
+
+CREATE TABLE report_duplicates AS
+SELECT r.*
+FROM reports r
+WHERE EXISTS (
+    SELECT 1
+    FROM reports newer
+    WHERE newer.worker_id = r.worker_id
+        AND newer.period = r.period
+        AND (COALESCE(newer.updated_at, newer.created_at, 'epoch'), newer.id) > (COALESCE(r.updated_at, r.created_at, 'epoch'), r.id)
+);
+
+DELETE FROM reports WHERE id IN (SELECT id FROM report_duplicates);
+
+ALTER TABLE reports DROP COLUMN month_of;
+ALTER TABLE reports RENAME COLUMN period TO month_of;
+ALTER TABLE reports ALTER COLUMN month_of SET NOT NULL;
+
+ALTER TABLE reports
+    ADD CONSTRAINT reports_worker_month_key UNIQUE (worker_id, month_of);
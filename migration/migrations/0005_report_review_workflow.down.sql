@@ -0,0 +1,6 @@
+ALTER TABLE reports
+    DROP COLUMN status,
+    DROP COLUMN review_comment,
+    DROP COLUMN submitted_at,
+    DROP COLUMN reviewed_at,
+    DROP COLUMN reviewed_by;
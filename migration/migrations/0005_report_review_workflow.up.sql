@@ -0,0 +1,17 @@
+-- Reports filed before the review workflow existed were final on creation,
+-- so they start out as submitted and wait for a supervisor like new ones.
+
+ALTER TABLE reports
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'submitted',
+    ADD COLUMN review_comment TEXT NOT NULL DEFAULT '',
+    ADD COLUMN submitted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN reviewed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN reviewed_by INTEGER REFERENCES users (id);
+
+UPDATE reports SET submitted_at = created_at;
+
+ALTER TABLE reports
+    ALTER COLUMN status SET DEFAULT 'draft',
+    ADD CONSTRAINT reports_status_check CHECK (status IN ('draft', 'submitted', 'approved', 'returned'));
+
+CREATE INDEX reports_status_idx ON reports (status);
@@ -0,0 +1,2 @@
+DROP TABLE report_revisions;
+DROP FUNCTION report_revisions_immutable();
@@ -0,0 +1,25 @@
+-- Every change to a report is kept as an immutable revision. There is no
+-- foreign key to reports so the history outlives the report itself.
+
+CREATE TABLE report_revisions (
+    id SERIAL PRIMARY KEY,
+    report_id INTEGER NOT NULL,
+    revision INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor_id INTEGER,
+    actor_username VARCHAR(100) NOT NULL DEFAULT '',
+    snapshot JSONB NOT NULL,
+    changes JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (report_id, revision)
+);
+
+CREATE FUNCTION report_revisions_immutable() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'report revisions cannot be changed or removed';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER report_revisions_immutable
+    BEFORE UPDATE OR DELETE ON report_revisions
+    FOR EACH ROW EXECUTE FUNCTION report_revisions_immutable();
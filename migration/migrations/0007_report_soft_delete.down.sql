@@ -0,0 +1,12 @@
+-- Reports still in the trash cannot be kept once the unique constraint
+-- covers every row again.
+DELETE FROM reports WHERE deleted_at IS NOT NULL;
+
+DROP INDEX reports_deleted_at_idx;
+DROP INDEX reports_worker_month_key;
+
+ALTER TABLE reports ADD CONSTRAINT reports_worker_month_key UNIQUE (worker_id, month_of);
+
+ALTER TABLE reports
+    DROP COLUMN deleted_by,
+    DROP COLUMN deleted_at;
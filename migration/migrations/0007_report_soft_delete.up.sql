@@ -0,0 +1,12 @@
+-- Deleted reports move to the trash instead of being removed, so the
+-- one-report-per-month rule only applies to the reports that are not deleted.
+
+ALTER TABLE reports
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN deleted_by INTEGER REFERENCES users (id);
+
+ALTER TABLE reports DROP CONSTRAINT reports_worker_month_key;
+
+CREATE UNIQUE INDEX reports_worker_month_key ON reports (worker_id, month_of) WHERE deleted_at IS NULL;
+
+CREATE INDEX reports_deleted_at_idx ON reports (deleted_at) WHERE deleted_at IS NOT NULL;
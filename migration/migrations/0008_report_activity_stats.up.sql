@@ -0,0 +1,41 @@
+-- Totals and averages of every activity are computed when a report is
+-- saved and kept in stats, keyed by activity, as
+-- {"total": 52, "weeks": 3, "average": 17.33}. Existing reports are filled
+-- in with the default rounding: half up to two decimals.
+
+ALTER TABLE reports ADD COLUMN stats JSONB NOT NULL DEFAULT '{}';
+
+CREATE FUNCTION pg_temp.activity_stats(weeks JSONB) RETURNS JSONB AS $$
+    SELECT jsonb_build_object(
+    'total', COALESCE(SUM(value::INTEGER), 0),
+    'weeks', COUNT(value),
+    'average', COALESCE(ROUND(AVG(value::INTEGER), 2), 0)
+    )
+    FROM jsonb_array_elements_text(
+        CASE WHEN jsonb_typeof(weeks) = 'array' THEN weeks ELSE '[]'::JSONB END
+    ) AS week(value)
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE reports SET stats = jsonb_build_object(
+    'worship_service', pg_temp.activity_stats(worship_service),
+    'sunday_school', pg_temp.activity_stats(sunday_school),
+    'prayer_meetings', pg_temp.activity_stats(prayer_meetings),
+    'bible_studies', pg_temp.activity_stats(bible_studies),
+    'mens_fellowships', pg_temp.activity_stats(mens_fellowships),
+    'womens_fellowships', pg_temp.activity_stats(womens_fellowships),
+    'youth_fellowships', pg_temp.activity_stats(youth_fellowships),
+    'child_fellowships', pg_temp.activity_stats(child_fellowships),
+    'outreach', pg_temp.activity_stats(outreach),
+    'training_or_seminars', pg_temp.activity_stats(training_or_seminars),
+    'leadership_conferences', pg_temp.activity_stats(leadership_conferences),
+    'leadership_training', pg_temp.activity_stats(leadership_training),
+    'others', pg_temp.activity_stats(others),
+    'family_days', pg_temp.activity_stats(family_days),
+    'tithes_and_offerings', pg_temp.activity_stats(tithes_and_offerings),
+    'home_visited', pg_temp.activity_stats(home_visited),
+    'bible_study_or_group_led', pg_temp.activity_stats(bible_study_or_group_led),
+    'sermon_or_message_preached', pg_temp.activity_stats(sermon_or_message_preached),
+    'person_newly_contacted', pg_temp.activity_stats(person_newly_contacted),
+    'person_followed_up', pg_temp.activity_stats(person_followed_up),
+    'person_led_to_christ', pg_temp.activity_stats(person_led_to_christ)
+);
@@ -0,0 +1,49 @@
+ALTER TABLE reports
+    ADD COLUMN worship_service JSONB,
+    ADD COLUMN sunday_school JSONB,
+    ADD COLUMN prayer_meetings JSONB,
+    ADD COLUMN bible_studies JSONB,
+    ADD COLUMN mens_fellowships JSONB,
+    ADD COLUMN womens_fellowships JSONB,
+    ADD COLUMN youth_fellowships JSONB,
+    ADD COLUMN child_fellowships JSONB,
+    ADD COLUMN outreach JSONB,
+    ADD COLUMN training_or_seminars JSONB,
+    ADD COLUMN leadership_conferences JSONB,
+    ADD COLUMN leadership_training JSONB,
+    ADD COLUMN others JSONB,
+    ADD COLUMN family_days JSONB,
+    ADD COLUMN tithes_and_offerings JSONB,
+    ADD COLUMN home_visited JSONB,
+    ADD COLUMN bible_study_or_group_led JSONB,
+    ADD COLUMN sermon_or_message_preached JSONB,
+    ADD COLUMN person_newly_contacted JSONB,
+    ADD COLUMN person_followed_up JSONB,
+    ADD COLUMN person_led_to_christ JSONB;
+
+UPDATE reports SET
+    worship_service = activities -> 'worship_service',
+    sunday_school = activities -> 'sunday_school',
+    prayer_meetings = activities -> 'prayer_meetings',
+    bible_studies = activities -> 'bible_studies',
+    mens_fellowships = activities -> 'mens_fellowships',
+    womens_fellowships = activities -> 'womens_fellowships',
+    youth_fellowships = activities -> 'youth_fellowships',
+    child_fellowships = activities -> 'child_fellowships',
+    outreach = activities -> 'outreach',
+    training_or_seminars = activities -> 'training_or_seminars',
+    leadership_conferences = activities -> 'leadership_conferences',
+    leadership_training = activities -> 'leadership_training',
+    others = activities -> 'others',
+    family_days = activities -> 'family_days',
+    tithes_and_offerings = activities -> 'tithes_and_offerings',
+    home_visited = activities -> 'home_visited',
+    bible_study_or_group_led = activities -> 'bible_study_or_group_led',
+    sermon_or_message_preached = activities -> 'sermon_or_message_preached',
+    person_newly_contacted = activities -> 'person_newly_contacted',
+    person_followed_up = activities -> 'person_followed_up',
+    person_led_to_christ = activities -> 'person_led_to_christ';
+
+ALTER TABLE reports DROP COLUMN activities;
+
+DROP TABLE activities;
@@ -0,0 +1,87 @@
+-- Activities become data: the catalog lists them and every report keeps its
+-- weekly values in one JSONB object keyed by activity, e.g.
+-- {"worship_service": [40, 42, 38], "home_visited": [3, 5]}.
+
+CREATE TABLE activities (
+    id SERIAL PRIMARY KEY,
+    key VARCHAR(50) NOT NULL UNIQUE CHECK (key ~ '^[a-z][a-z0-9_]*$'),
+    label VARCHAR(100) NOT NULL,
+    category VARCHAR(30) NOT NULL CHECK (category IN ('attendance', 'personal_ministry')),
+    display_order INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO activities (key, label, category, display_order) VALUES
+    ('worship_service', 'Worship Service', 'attendance', 10),
+    ('sunday_school', 'Sunday School', 'attendance', 20),
+    ('prayer_meetings', 'Prayer Meetings', 'attendance', 30),
+    ('bible_studies', 'Bible Studies', 'attendance', 40),
+    ('mens_fellowships', 'Mens Fellowships', 'attendance', 50),
+    ('womens_fellowships', 'Womens Fellowships', 'attendance', 60),
+    ('youth_fellowships', 'Youth Fellowships', 'attendance', 70),
+    ('child_fellowships', 'Child Fellowships', 'attendance', 80),
+    ('outreach', 'Outreach', 'attendance', 90),
+    ('training_or_seminars', 'Training Or Seminars', 'attendance', 100),
+    ('leadership_conferences', 'Leadership Conferences', 'attendance', 110),
+    ('leadership_training', 'Leadership Training', 'attendance', 120),
+    ('others', 'Others', 'attendance', 130),
+    ('family_days', 'Family Days', 'attendance', 140),
+    ('tithes_and_offerings', 'Tithes And Offerings', 'attendance', 150),
+    ('home_visited', 'Home Visited', 'personal_ministry', 160),
+    ('bible_study_or_group_led', 'Bible Study Group Led', 'personal_ministry', 170),
+    ('sermon_or_message_preached', 'Sermon/Message Preached', 'personal_ministry', 180),
+    ('person_newly_contacted', 'Person Newly Contacted', 'personal_ministry', 190),
+    ('person_followed_up', 'Person Followed-Up', 'personal_ministry', 200),
+    ('person_led_to_christ', 'Person Led To Christ', 'personal_ministry', 210);
+
+ALTER TABLE reports ADD COLUMN activities JSONB NOT NULL DEFAULT '{}';
+
+-- Activities that were never filled in are stored as JSON null; leave them out
+UPDATE reports SET activities = jsonb_strip_nulls(jsonb_build_object(
+    'worship_service', worship_service,
+    'sunday_school', sunday_school,
+    'prayer_meetings', prayer_meetings,
+    'bible_studies', bible_studies,
+    'mens_fellowships', mens_fellowships,
+    'womens_fellowships', womens_fellowships,
+    'youth_fellowships', youth_fellowships,
+    'child_fellowships', child_fellowships,
+    'outreach', outreach,
+    'training_or_seminars', training_or_seminars,
+    'leadership_conferences', leadership_conferences,
+    'leadership_training', leadership_training,
+    'others', others,
+    'family_days', family_days,
+    'tithes_and_offerings', tithes_and_offerings,
+    'home_visited', home_visited,
+    'bible_study_or_group_led', bible_study_or_group_led,
+    'sermon_or_message_preached', sermon_or_message_preached,
+    'person_newly_contacted', person_newly_contacted,
+    'person_followed_up', person_followed_up,
+    'person_led_to_christ', person_led_to_christ
+));
+
+ALTER TABLE reports
+    DROP COLUMN worship_service,
+    DROP COLUMN sunday_school,
+    DROP COLUMN prayer_meetings,
+    DROP COLUMN bible_studies,
+    DROP COLUMN mens_fellowships,
+    DROP COLUMN womens_fellowships,
+    DROP COLUMN youth_fellowships,
+    DROP COLUMN child_fellowships,
+    DROP COLUMN outreach,
+    DROP COLUMN training_or_seminars,
+    DROP COLUMN leadership_conferences,
+    DROP COLUMN leadership_training,
+    DROP COLUMN others,
+    DROP COLUMN family_days,
+    DROP COLUMN tithes_and_offerings,
+    DROP COLUMN home_visited,
+    DROP COLUMN bible_study_or_group_led,
+    DROP COLUMN sermon_or_message_preached,
+    DROP COLUMN person_newly_contacted,
+    DROP COLUMN person_followed_up,
+    DROP COLUMN person_led_to_christ;
@@ -0,0 +1,3 @@
+DROP INDEX reports_search_vector_idx;
+
+ALTER TABLE reports DROP COLUMN search_vector;
@@ -0,0 +1,15 @@
+-- Full-text search over the free text sections. Postgres has no Filipino
+-- configuration, so every section is indexed twice: stemmed with 'english'
+-- and word for word with 'simple', which keeps Tagalog and Taglish words
+-- searchable as written. Narratives weigh more than challenges, which weigh
+-- more than prayer requests.
+ALTER TABLE reports ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(narrative_report, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(narrative_report, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(challenges_and_problem_encountered, '')), 'B') ||
+    setweight(to_tsvector('simple', COALESCE(challenges_and_problem_encountered, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(prayer_request, '')), 'C') ||
+    setweight(to_tsvector('simple', COALESCE(prayer_request, '')), 'C')
+) STORED;
+
+CREATE INDEX reports_search_vector_idx ON reports USING GIN (search_vector);
@@ -0,0 +1,3 @@
+-- Workers who left the roster keep their reports but are no longer expected
+-- to file new ones.
+ALTER TABLE workers ADD COLUMN active BOOLEAN NOT NULL DEFAULT TRUE;
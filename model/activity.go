@@ -0,0 +1,176 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	ActivityCategoryAttendance       = "attendance"
+	ActivityCategoryPersonalMinistry = "personal_ministry"
+)
+
+// ReportWeeks is the most weeks of values an activity can have in a month.
+const ReportWeeks = 5
+
+// Activity is one line of the weekly table of the monthly report. Reports
+// keep their values under Key, so the key cannot change once it is in use;
+// retired activities are made inactive instead of being removed.
+type Activity struct {
+	Id           int       `json:"id"`
+	Key          string    `json:"key"`
+	Label        string    `json:"label"`
+	Category     string    `json:"category"`
+	DisplayOrder int       `json:"display_order"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+var activityKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// IsValidActivityKey reports whether key is a lower case identifier that can
+// be used as a JSON name and in export column names.
+func IsValidActivityKey(key string) bool {
+	return activityKeyPattern.MatchString(key)
+}
+
+func IsValidActivityCategory(category string) bool {
+	switch category {
+	case ActivityCategoryAttendance, ActivityCategoryPersonalMinistry:
+		return true
+	}
+	return false
+}
+
+// ActivityCatalog is the list of activities in display order.
+type ActivityCatalog []*Activity
+
+// Find returns the activity with the given key, or nil.
+func (c ActivityCatalog) Find(key string) *Activity {
+	for _, activity := range c {
+		if activity.Key == key {
+			return activity
+		}
+	}
+	return nil
+}
+
+// Active lists the activities new reports are filled in with.
+func (c ActivityCatalog) Active() ActivityCatalog {
+	active := ActivityCatalog{}
+	for _, activity := range c {
+		if activity.Active {
+			active = append(active, activity)
+		}
+	}
+	return active
+}
+
+// ForReport lists the active activities and the inactive ones report still
+// has values for, so old reports print the way they were filed.
+func (c ActivityCatalog) ForReport(report *Report) ActivityCatalog {
+	activities := ActivityCatalog{}
+	for _, activity := range c {
+		if _, ok := report.Activities[activity.Key]; activity.Active || ok {
+			activities = append(activities, activity)
+		}
+	}
+	return activities
+}
+
+// ActivityValues holds the weekly values of a report by activity key. It is
+// stored in a JSONB column.
+type ActivityValues map[string][]int
+
+func (v ActivityValues) Value() (driver.Value, error) {
+	if v == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(v)
+}
+
+func (v *ActivityValues) Scan(src interface{}) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+		*v = ActivityValues{}
+		return nil
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("cannot scan %T into ActivityValues", src)
+	}
+
+	values := ActivityValues{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*v = values
+	return nil
+}
+
+// LegacyActivityKeys are the activities that used to be fields of Report,
+// sent as top-level JSON arrays like "worship_service": [10, 12].
+var LegacyActivityKeys = []string{
+	"worship_service",
+	"sunday_school",
+	"prayer_meetings",
+	"bible_studies",
+	"mens_fellowships",
+	"womens_fellowships",
+	"youth_fellowships",
+	"child_fellowships",
+	"outreach",
+	"training_or_seminars",
+	"leadership_conferences",
+	"leadership_training",
+	"others",
+	"family_days",
+	"tithes_and_offerings",
+	"home_visited",
+	"bible_study_or_group_led",
+	"sermon_or_message_preached",
+	"person_newly_contacted",
+	"person_followed_up",
+	"person_led_to_christ",
+}
+
+// MergeLegacyActivities adds the legacy top-level activity arrays of a JSON
+// object to values, without overwriting keys values already has. It lets
+// older clients and revisions saved before the catalog keep working.
+func MergeLegacyActivities(data []byte, values *ActivityValues) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for _, key := range LegacyActivityKeys {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+
+		var weeks []int
+		if err := json.Unmarshal(raw, &weeks); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if weeks == nil {
+			continue
+		}
+
+		if *values == nil {
+			*values = ActivityValues{}
+		}
+		if _, exists := (*values)[key]; !exists {
+			(*values)[key] = weeks
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestReportUnmarshalLegacyActivities(t *testing.T) {
+	data := `{"worship_service": [10, 12], "activities": {"baptisms": [2], "worship_service": [1]}, "outreach": null}`
+
+	var report Report
+	if err := json.Unmarshal([]byte(data), &report); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	// The activities object wins over the legacy field of the same key
+	want := ActivityValues{"baptisms": {2}, "worship_service": {1}}
+	if !reflect.DeepEqual(report.Activities, want) {
+		t.Fatalf("activities = %v, want %v", report.Activities, want)
+	}
+
+	var legacy Report
+	if err := json.Unmarshal([]byte(`{"sunday_school": [3, 4]}`), &legacy); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if want := (ActivityValues{"sunday_school": {3, 4}}); !reflect.DeepEqual(legacy.Activities, want) {
+		t.Fatalf("legacy activities = %v, want %v", legacy.Activities, want)
+	}
+}
+
+func TestActivityCatalogForReport(t *testing.T) {
+	catalog := ActivityCatalog{
+		{Key: "worship_service", Active: true},
+		{Key: "family_days"},
+		{Key: "outreach"},
+	}
+	report := &Report{Activities: ActivityValues{"outreach": {1}}}
+
+	var keys []string
+	for _, activity := range catalog.ForReport(report) {
+		keys = append(keys, activity.Key)
+	}
+	if want := []string{"worship_service", "outreach"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("ForReport() = %v, want %v", keys, want)
+	}
+}
@@ -0,0 +1,283 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dimensions the analytics summary can be grouped by.
+const (
+	GroupByArea   = "area"
+	GroupByChurch = "church"
+	GroupByWorker = "worker"
+	GroupByPeriod = "period"
+)
+
+// Granularities of the period dimension.
+const (
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+	PeriodYear    = "year"
+)
+
+// AnalyticsQuery asks for activity totals of the reports matching Filter,
+// grouped by the dimensions of GroupBy. Paging, sort and fields of Filter
+// are ignored. Without a status in Filter only submitted and approved
+// reports are counted.
+type AnalyticsQuery struct {
+	Filter  SearchReportQuery
+	GroupBy []string
+	Period  string
+}
+
+// ParseGroupBy reads a comma separated list of dimensions, each at most
+// once. An empty value groups all reports together.
+func ParseGroupBy(value string) ([]string, error) {
+	var groupBy []string
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		switch part {
+		case "":
+			continue
+		case GroupByArea, GroupByChurch, GroupByWorker, GroupByPeriod:
+		default:
+			return nil, fmt.Errorf("cannot group by %q, use area, church, worker or period", part)
+		}
+
+		if !seen[part] {
+			seen[part] = true
+			groupBy = append(groupBy, part)
+		}
+	}
+
+	return groupBy, nil
+}
+
+// IsValidPeriodGranularity reports whether period is month, quarter or year.
+func IsValidPeriodGranularity(period string) bool {
+	switch period {
+	case PeriodMonth, PeriodQuarter, PeriodYear:
+		return true
+	}
+	return false
+}
+
+// ActivitySummary adds up one activity over the reports of a group.
+// WeeklyAverage is per reported week, ReportAverage per report listing the
+// activity.
+type ActivitySummary struct {
+	Label         string  `json:"label"`
+	Total         int     `json:"total"`
+	Weeks         int     `json:"weeks"`
+	Reports       int     `json:"reports"`
+	WeeklyAverage float64 `json:"weekly_average"`
+	ReportAverage float64 `json:"report_average"`
+}
+
+// AnalyticsGroup holds the totals of one combination of dimensions. Only
+// the fields of the grouped dimensions are set.
+type AnalyticsGroup struct {
+	AreaId           int    `json:"area_id,omitempty"`
+	AreaOfAssignment string `json:"area_of_assignment,omitempty"`
+	ChurchId         int    `json:"church_id,omitempty"`
+	NameOfChurch     string `json:"name_of_church,omitempty"`
+	WorkerId         int    `json:"worker_id,omitempty"`
+	WorkerName       string `json:"worker_name,omitempty"`
+
+	// Period is formatted as 2024-01, 2024-Q1 or 2024.
+	Period string `json:"period,omitempty"`
+
+	Reports    int                         `json:"reports"`
+	Activities map[string]*ActivitySummary `json:"activities"`
+}
+
+// Add sums other into g, leaving averages to Finish.
+func (g *AnalyticsGroup) Add(other *AnalyticsGroup) {
+	g.Reports += other.Reports
+	if g.Activities == nil {
+		g.Activities = map[string]*ActivitySummary{}
+	}
+
+	for key, activity := range other.Activities {
+		sum, ok := g.Activities[key]
+		if !ok {
+			sum = &ActivitySummary{Label: activity.Label}
+			g.Activities[key] = sum
+		}
+		sum.Total += activity.Total
+		sum.Weeks += activity.Weeks
+		sum.Reports += activity.Reports
+	}
+}
+
+// Finish works out the rounded averages of every activity.
+func (g *AnalyticsGroup) Finish(rounding AverageRounding) {
+	for _, activity := range g.Activities {
+		activity.WeeklyAverage, activity.ReportAverage = 0, 0
+		if activity.Weeks > 0 {
+			activity.WeeklyAverage = rounding.Round(float64(activity.Total) / float64(activity.Weeks))
+		}
+		if activity.Reports > 0 {
+			activity.ReportAverage = rounding.Round(float64(activity.Total) / float64(activity.Reports))
+		}
+	}
+}
+
+// AnalyticsSummary is the answer to an AnalyticsQuery. Totals covers every
+// matching report; each report falls in exactly one of Groups.
+type AnalyticsSummary struct {
+	GroupBy []string          `json:"group_by"`
+	Period  string            `json:"period,omitempty"`
+	Totals  *AnalyticsGroup   `json:"totals"`
+	Groups  []*AnalyticsGroup `json:"groups"`
+}
+
+// MaxTrendMonths bounds the length of a trend series.
+const MaxTrendMonths = 120
+
+// TrendQuery asks for the monthly series of one measure of an activity over
+// the reports matching Filter, from From to To included. The worker, church
+// and area filters of Filter pick the scope; without them it is national.
+// As for AnalyticsQuery, only submitted and approved reports are counted
+// unless Filter asks for a status.
+type TrendQuery struct {
+	Filter   SearchReportQuery
+	Activity string
+	Measure  string
+	From     Period
+	To       Period
+}
+
+func (q *TrendQuery) Validate() error {
+	if !IsValidActivityKey(q.Activity) {
+		return fmt.Errorf("invalid activity %q", q.Activity)
+	}
+
+	switch q.Measure {
+	case MeasureTotal, MeasureAverage, MeasureWeeks:
+	default:
+		return fmt.Errorf("invalid measure %q, use total, average or weeks", q.Measure)
+	}
+
+	if q.From.IsZero() || q.To.IsZero() || q.To.Time().Before(q.From.Time()) {
+		return fmt.Errorf("invalid range, month_from must not be after month_to")
+	}
+	if MonthsBetween(q.From, q.To) >= MaxTrendMonths {
+		return fmt.Errorf("range is longer than %d months", MaxTrendMonths)
+	}
+
+	return nil
+}
+
+// MonthsBetween counts the months from a to b.
+func MonthsBetween(a, b Period) int {
+	return (b.Year-a.Year)*12 + int(b.Month) - int(a.Month)
+}
+
+// MonthlyActivity sums up an activity over the reports of one month.
+// ActivityReports counts the reports that list the activity at all.
+type MonthlyActivity struct {
+	Month           Period
+	Reports         int
+	ActivityReports int
+	Total           int
+	Weeks           int
+}
+
+// TrendChange compares a month with an earlier one. Percent is nil when the
+// earlier value is zero.
+type TrendChange struct {
+	Delta   float64  `json:"delta"`
+	Percent *float64 `json:"percent"`
+}
+
+// TrendPoint is one month of a series. Value, and the changes relying on
+// it, are nil when no report of the month lists the activity; a month
+// without reports is not a month of zeros.
+type TrendPoint struct {
+	Month           Period       `json:"month"`
+	Reports         int          `json:"reports"`
+	ActivityReports int          `json:"activity_reports"`
+	Value           *float64     `json:"value"`
+	MoM             *TrendChange `json:"mom"`
+	YoY             *TrendChange `json:"yoy"`
+}
+
+// ScopeNational is the scope of a series over every area.
+const ScopeNational = "national"
+
+// TrendSeries is the answer to a TrendQuery.
+type TrendSeries struct {
+	Activity string        `json:"activity"`
+	Label    string        `json:"label"`
+	Measure  string        `json:"measure"`
+	Scope    string        `json:"scope"` // worker, church, area or national
+	ScopeId  int           `json:"scope_id,omitempty"`
+	From     Period        `json:"month_from"`
+	To       Period        `json:"month_to"`
+	Points   []*TrendPoint `json:"points"`
+}
+
+// TrendPoints turns the months from 12 months before from up to to into
+// the points from from on, comparing each with the month and the year
+// before. months must hold every month of that range in order.
+func TrendPoints(months []MonthlyActivity, from Period, measure string, rounding AverageRounding) []*TrendPoint {
+	values := make(map[Period]*float64, len(months))
+	for _, month := range months {
+		values[month.Month] = month.value(measure, rounding)
+	}
+
+	points := []*TrendPoint{}
+	for _, month := range months {
+		if month.Month.Time().Before(from.Time()) {
+			continue
+		}
+
+		value := values[month.Month]
+		points = append(points, &TrendPoint{
+			Month:           month.Month,
+			Reports:         month.Reports,
+			ActivityReports: month.ActivityReports,
+			Value:           value,
+			MoM:             trendChange(value, values[month.Month.AddMonths(-1)], rounding),
+			YoY:             trendChange(value, values[month.Month.AddMonths(-12)], rounding),
+		})
+	}
+
+	return points
+}
+
+func (m MonthlyActivity) value(measure string, rounding AverageRounding) *float64 {
+	if m.ActivityReports == 0 {
+		return nil
+	}
+
+	var value float64
+	switch measure {
+	case MeasureAverage:
+		if m.Weeks == 0 {
+			return nil
+		}
+		value = rounding.Round(float64(m.Total) / float64(m.Weeks))
+	case MeasureWeeks:
+		value = float64(m.Weeks)
+	default:
+		value = float64(m.Total)
+	}
+	return &value
+}
+
+func trendChange(value, earlier *float64, rounding AverageRounding) *TrendChange {
+	if value == nil || earlier == nil {
+		return nil
+	}
+
+	change := &TrendChange{Delta: rounding.Round(*value - *earlier)}
+	if *earlier != 0 {
+		percent := rounding.Round((*value - *earlier) / *earlier * 100)
+		change.Percent = &percent
+	}
+	return change
+}
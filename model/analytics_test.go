@@ -0,0 +1,67 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	groupBy, err := ParseGroupBy("area, period,area")
+	if err != nil {
+		t.Fatalf("ParseGroupBy() error = %v", err)
+	}
+	if want := []string{GroupByArea, GroupByPeriod}; !reflect.DeepEqual(groupBy, want) {
+		t.Fatalf("ParseGroupBy() = %v, want %v", groupBy, want)
+	}
+
+	if _, err := ParseGroupBy("area;DROP TABLE reports"); err == nil {
+		t.Fatal("ParseGroupBy() accepted an unknown dimension")
+	}
+}
+
+func TestAnalyticsGroupTotals(t *testing.T) {
+	totals := &AnalyticsGroup{}
+	totals.Add(&AnalyticsGroup{Reports: 2, Activities: map[string]*ActivitySummary{
+		"worship_service": {Label: "Worship Service", Total: 30, Weeks: 6, Reports: 2},
+	}})
+	totals.Add(&AnalyticsGroup{Reports: 1, Activities: map[string]*ActivitySummary{
+		"worship_service": {Label: "Worship Service", Total: 10, Weeks: 4, Reports: 1},
+	}})
+	totals.Finish(DefaultAverageRounding)
+
+	want := &ActivitySummary{Label: "Worship Service", Total: 40, Weeks: 10, Reports: 3, WeeklyAverage: 4, ReportAverage: 13.33}
+	if totals.Reports != 3 || !reflect.DeepEqual(totals.Activities["worship_service"], want) {
+		t.Fatalf("totals = %d reports, %+v, want 3 reports, %+v", totals.Reports, totals.Activities["worship_service"], want)
+	}
+}
+
+func TestTrendPoints(t *testing.T) {
+	from := Period{Year: 2024, Month: 1}
+	var months []MonthlyActivity
+	// January 2023 up to April 2024
+	for i := -12; i <= 3; i++ {
+		months = append(months, MonthlyActivity{Month: from.AddMonths(i)})
+	}
+	set := func(period Period, total int) {
+		months[MonthsBetween(from.AddMonths(-12), period)] = MonthlyActivity{Month: period, Reports: 1, ActivityReports: 1, Total: total, Weeks: 4}
+	}
+	set(Period{Year: 2023, Month: 1}, 0)
+	set(Period{Year: 2024, Month: 1}, 40)
+	set(Period{Year: 2024, Month: 2}, 50)
+	// March 2024 has no report, April 2024 follows the gap
+
+	points := TrendPoints(months, from, MeasureTotal, DefaultAverageRounding)
+	if len(points) != 4 || points[0].Month != from {
+		t.Fatalf("TrendPoints() = %d points from %v, want 4 from %v", len(points), points[0].Month, from)
+	}
+
+	if points[0].YoY == nil || points[0].YoY.Delta != 40 || points[0].YoY.Percent != nil {
+		t.Fatalf("January YoY = %+v, want delta 40 without percent", points[0].YoY)
+	}
+	if points[1].MoM == nil || points[1].MoM.Delta != 10 || *points[1].MoM.Percent != 25 {
+		t.Fatalf("February MoM = %+v, want +10 (25%%)", points[1].MoM)
+	}
+	if points[2].Value != nil || points[2].MoM != nil || points[3].MoM != nil {
+		t.Fatalf("a month without reports must have no value or change, got %+v and %+v", points[2], points[3])
+	}
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type Church struct {
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	AreaId    int       `json:"area_id"`
+	AreaName  string    `json:"area_name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,231 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultComplianceHistory is the number of months, the asked one included,
+// that streaks and average delays look back on.
+const DefaultComplianceHistory = 12
+
+// CheckDeadlineDay checks the day of the month reports are due, which must
+// exist in every month.
+func CheckDeadlineDay(day int) error {
+	if day < 1 || day > 28 {
+		return fmt.Errorf("invalid report deadline day %d, use 1 to 28", day)
+	}
+	return nil
+}
+
+// ReportDeadline returns the moment the report of month becomes late: the
+// end of deadlineDay of the following month in loc.
+func ReportDeadline(month Period, deadlineDay int, loc *time.Location) time.Time {
+	next := month.AddMonths(1)
+	return time.Date(next.Year, next.Month, deadlineDay+1, 0, 0, 0, 0, loc)
+}
+
+// ComplianceQuery asks which active workers submitted their report for
+// Month, limited to one area when AreaId is set.
+type ComplianceQuery struct {
+	Month   Period
+	AreaId  int
+	History int
+}
+
+func (q *ComplianceQuery) Validate() error {
+	if q.Month.IsZero() {
+		return fmt.Errorf("month_of must not be empty")
+	}
+	if q.History < 1 || q.History > MaxTrendMonths {
+		return fmt.Errorf("history must be between 1 and %d months", MaxTrendMonths)
+	}
+	return nil
+}
+
+// Submission is what compliance needs of a report.
+type Submission struct {
+	Month       Period
+	Status      string
+	SubmittedAt *time.Time
+}
+
+// Submitted reports whether the report was sent in and not returned since.
+func (s Submission) Submitted() bool {
+	return (s.Status == ReportStatusSubmitted || s.Status == ReportStatusApproved) && s.SubmittedAt != nil
+}
+
+// RosterWorker is an active worker with their reports of the tracked months.
+type RosterWorker struct {
+	Worker
+	Reports []Submission
+}
+
+// WorkerCompliance is where a worker stands for the asked month. Status is
+// the status of their report, or "missing" without one. Delays are in days
+// after the deadline, negative when early.
+type WorkerCompliance struct {
+	WorkerId         int        `json:"worker_id"`
+	WorkerName       string     `json:"worker_name"`
+	AreaId           int        `json:"area_id,omitempty"`
+	AreaOfAssignment string     `json:"area_of_assignment,omitempty"`
+	ChurchId         int        `json:"church_id,omitempty"`
+	NameOfChurch     string     `json:"name_of_church,omitempty"`
+	Status           string     `json:"status"`
+	Submitted        bool       `json:"submitted"`
+	SubmittedAt      *time.Time `json:"submitted_at,omitempty"`
+	DelayDays        *float64   `json:"delay_days"`
+	OnTime           bool       `json:"on_time"`
+
+	// Streak counts the months up to the asked one submitted in a row.
+	Streak int `json:"streak"`
+
+	// AverageDelayDays covers the submitted reports of the history.
+	AverageDelayDays *float64 `json:"average_delay_days"`
+}
+
+// ReportMissing is the status of a worker without a report for the month.
+const ReportMissing = "missing"
+
+// AreaCompliance sums up the workers of an area. Compliance is the share of
+// workers who submitted, in percent; AverageDelayDays covers the month's
+// submissions.
+type AreaCompliance struct {
+	AreaId           int      `json:"area_id,omitempty"`
+	AreaOfAssignment string   `json:"area_of_assignment,omitempty"`
+	Workers          int      `json:"workers"`
+	Submitted        int      `json:"submitted"`
+	OnTime           int      `json:"on_time"`
+	Missing          int      `json:"missing"`
+	Compliance       float64  `json:"compliance"`
+	AverageDelayDays *float64 `json:"average_delay_days"`
+
+	delaySum float64
+}
+
+func (a *AreaCompliance) add(worker *WorkerCompliance) {
+	a.Workers++
+	if !worker.Submitted {
+		a.Missing++
+		return
+	}
+
+	a.Submitted++
+	if worker.OnTime {
+		a.OnTime++
+	}
+	if worker.DelayDays != nil {
+		a.delaySum += *worker.DelayDays
+	}
+}
+
+func (a *AreaCompliance) finish(rounding AverageRounding) {
+	if a.Workers > 0 {
+		a.Compliance = rounding.Round(float64(a.Submitted) / float64(a.Workers) * 100)
+	}
+	if a.Submitted > 0 {
+		average := rounding.Round(a.delaySum / float64(a.Submitted))
+		a.AverageDelayDays = &average
+	}
+}
+
+// ComplianceReport is the answer to a ComplianceQuery. Missing lists the
+// workers of Workers who did not submit.
+type ComplianceReport struct {
+	Month    Period              `json:"month_of"`
+	Deadline time.Time           `json:"deadline"`
+	History  int                 `json:"history_months"`
+	Totals   *AreaCompliance     `json:"totals"`
+	Areas    []*AreaCompliance   `json:"areas"`
+	Missing  []*WorkerCompliance `json:"missing"`
+	Workers  []*WorkerCompliance `json:"workers"`
+}
+
+// BuildCompliance works out the compliance of the roster for query.Month,
+// with reports due deadlineDay of the next month in loc. Areas keep the
+// order of the roster.
+func BuildCompliance(roster []*RosterWorker, query *ComplianceQuery, deadlineDay int, loc *time.Location, rounding AverageRounding) *ComplianceReport {
+	report := &ComplianceReport{
+		Month:    query.Month,
+		Deadline: ReportDeadline(query.Month, deadlineDay, loc),
+		History:  query.History,
+		Totals:   &AreaCompliance{},
+		Areas:    []*AreaCompliance{},
+		Missing:  []*WorkerCompliance{},
+		Workers:  []*WorkerCompliance{},
+	}
+
+	areas := map[int]*AreaCompliance{}
+	for _, worker := range roster {
+		compliance := workerCompliance(worker, query, deadlineDay, loc, rounding)
+		report.Workers = append(report.Workers, compliance)
+		if !compliance.Submitted {
+			report.Missing = append(report.Missing, compliance)
+		}
+
+		area, ok := areas[worker.AreaId]
+		if !ok {
+			area = &AreaCompliance{AreaId: worker.AreaId, AreaOfAssignment: worker.AreaName}
+			areas[worker.AreaId] = area
+			report.Areas = append(report.Areas, area)
+		}
+		area.add(compliance)
+		report.Totals.add(compliance)
+	}
+
+	for _, area := range report.Areas {
+		area.finish(rounding)
+	}
+	report.Totals.finish(rounding)
+
+	return report
+}
+
+func workerCompliance(worker *RosterWorker, query *ComplianceQuery, deadlineDay int, loc *time.Location, rounding AverageRounding) *WorkerCompliance {
+	compliance := &WorkerCompliance{
+		WorkerId:         worker.Id,
+		WorkerName:       worker.Name,
+		AreaId:           worker.AreaId,
+		AreaOfAssignment: worker.AreaName,
+		ChurchId:         worker.ChurchId,
+		NameOfChurch:     worker.ChurchName,
+		Status:           ReportMissing,
+	}
+
+	submitted := map[Period]Submission{}
+	var delaySum float64
+	for _, report := range worker.Reports {
+		if report.Month == query.Month {
+			compliance.Status = report.Status
+		}
+		if !report.Submitted() {
+			continue
+		}
+
+		submitted[report.Month] = report
+		delaySum += report.SubmittedAt.Sub(ReportDeadline(report.Month, deadlineDay, loc)).Hours() / 24
+	}
+
+	if len(submitted) > 0 {
+		average := rounding.Round(delaySum / float64(len(submitted)))
+		compliance.AverageDelayDays = &average
+	}
+
+	if report, ok := submitted[query.Month]; ok {
+		delay := report.SubmittedAt.Sub(ReportDeadline(query.Month, deadlineDay, loc))
+		days := rounding.Round(delay.Hours() / 24)
+		compliance.Submitted = true
+		compliance.SubmittedAt = report.SubmittedAt
+		compliance.DelayDays = &days
+		compliance.OnTime = delay < 0
+	}
+
+	for month := query.Month; compliance.Streak < query.History; month = month.AddMonths(-1) {
+		if _, ok := submitted[month]; !ok {
+			break
+		}
+		compliance.Streak++
+	}
+
+	return compliance
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildCompliance(t *testing.T) {
+	march := Period{Year: 2024, Month: 3}
+	submitted := func(month Period, at time.Time) Submission {
+		return Submission{Month: month, Status: ReportStatusApproved, SubmittedAt: &at}
+	}
+
+	roster := []*RosterWorker{
+		{Worker: Worker{Id: 1, Name: "Ana", AreaId: 1, AreaName: "North"}, Reports: []Submission{
+			submitted(Period{Year: 2024, Month: 1}, time.Date(2024, 2, 3, 12, 0, 0, 0, time.UTC)),
+			submitted(Period{Year: 2024, Month: 2}, time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)),
+			submitted(march, time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC)),
+		}},
+		{Worker: Worker{Id: 2, Name: "Ben", AreaId: 1, AreaName: "North"}, Reports: []Submission{
+			{Month: march, Status: ReportStatusDraft},
+		}},
+		{Worker: Worker{Id: 3, Name: "Cy", AreaId: 2, AreaName: "South"}},
+	}
+
+	query := &ComplianceQuery{Month: march, History: 12}
+	report := BuildCompliance(roster, query, 5, time.UTC, DefaultAverageRounding)
+
+	if want := time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC); !report.Deadline.Equal(want) {
+		t.Fatalf("Deadline = %v, want %v", report.Deadline, want)
+	}
+
+	ana := report.Workers[0]
+	if !ana.Submitted || ana.OnTime || *ana.DelayDays != 1 || ana.Streak != 3 {
+		t.Fatalf("Ana = %+v, want submitted a day late with a streak of 3", ana)
+	}
+	// Two and a half days early, half a day early and a day late
+	if *ana.AverageDelayDays != -0.67 {
+		t.Fatalf("Ana average delay = %v, want -0.67", *ana.AverageDelayDays)
+	}
+
+	if len(report.Missing) != 2 || report.Missing[0].Status != ReportStatusDraft || report.Missing[1].Status != ReportMissing {
+		t.Fatalf("Missing = %+v, want Ben's draft and Cy", report.Missing)
+	}
+
+	if len(report.Areas) != 2 || report.Areas[0].Compliance != 50 || report.Areas[1].Compliance != 0 {
+		t.Fatalf("Areas = %+v, want North at 50%% and South at 0%%", report.Areas)
+	}
+	if report.Totals.Workers != 3 || report.Totals.Compliance != 33.33 {
+		t.Fatalf("Totals = %+v, want 3 workers at 33.33%%", report.Totals)
+	}
+}
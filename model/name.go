@@ -0,0 +1,9 @@
+package model
+
+import "strings"
+
+// NormalizeName trims a name and collapses repeated whitespace so that
+// "Juan  Dela Cruz " and "Juan Dela Cruz" are stored the same way.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
@@ -0,0 +1,178 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Manila is the time zone reports are filed and dated in. The Philippines
+// keep UTC+8 all year, so the fixed zone stands in when the system has no
+// time zone database.
+var Manila = loadManila()
+
+func loadManila() *time.Location {
+	loc, err := time.LoadLocation("Asia/Manila")
+	if err != nil {
+		return time.FixedZone("PHT", 8*60*60)
+	}
+	return loc
+}
+
+// Period is the calendar month a report covers.
+type Period struct {
+	Year  int
+	Month time.Month
+}
+
+var (
+	isoPeriodPattern     = regexp.MustCompile(`^(\d{4})[-/](\d{1,2})$`)
+	numericPeriodPattern = regexp.MustCompile(`^(\d{1,2})[-/](\d{4})$`)
+	namedPeriodPattern   = regexp.MustCompile(`^([a-z]+)\.?,?\s*(\d{4})$`)
+)
+
+// ParsePeriod accepts the ways workers write a month: "January 2024",
+// "Jan 2024", "2024-01", "2024/1" and "01/2024".
+func ParsePeriod(input string) (Period, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+
+	if match := isoPeriodPattern.FindStringSubmatch(value); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		return newPeriod(year, month, input)
+	}
+
+	if match := numericPeriodPattern.FindStringSubmatch(value); match != nil {
+		month, _ := strconv.Atoi(match[1])
+		year, _ := strconv.Atoi(match[2])
+		return newPeriod(year, month, input)
+	}
+
+	if match := namedPeriodPattern.FindStringSubmatch(value); match != nil {
+		year, _ := strconv.Atoi(match[2])
+		for month := time.January; month <= time.December; month++ {
+			name := strings.ToLower(month.String())
+			if match[1] == name || (len(match[1]) >= 3 && strings.HasPrefix(name, match[1])) {
+				return newPeriod(year, int(month), input)
+			}
+		}
+	}
+
+	return Period{}, fmt.Errorf("invalid month %q, use a value like \"January 2024\" or \"2024-01\"", input)
+}
+
+func newPeriod(year, month int, value string) (Period, error) {
+	if month < 1 || month > 12 || year < 1900 || year > 9999 {
+		return Period{}, fmt.Errorf("invalid month %q, use a value like \"January 2024\" or \"2024-01\"", value)
+	}
+
+	return Period{Year: year, Month: time.Month(month)}, nil
+}
+
+// PeriodOf returns the period containing t.
+func PeriodOf(t time.Time) Period {
+	return Period{Year: t.Year(), Month: t.Month()}
+}
+
+func (p Period) IsZero() bool {
+	return p.Year == 0 && p.Month == 0
+}
+
+// Time returns the first day of the period in UTC.
+func (p Period) Time() time.Time {
+	return time.Date(p.Year, p.Month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// AddMonths returns the period n months after p, or before it when n is negative.
+func (p Period) AddMonths(n int) Period {
+	return PeriodOf(p.Time().AddDate(0, n, 0))
+}
+
+// String formats the period as "2024-01".
+func (p Period) String() string {
+	if p.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d", p.Year, int(p.Month))
+}
+
+// Label formats the period as "January 2024", the way it is printed on the
+// monthly report.
+func (p Period) Label() string {
+	if p.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%s %d", p.Month, p.Year)
+}
+
+func (p Period) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *Period) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if value == "" {
+		*p = Period{}
+		return nil
+	}
+
+	period, err := ParsePeriod(value)
+	if err != nil {
+		return err
+	}
+
+	*p = period
+	return nil
+}
+
+// Scan implements sql.Scanner for DATE columns.
+func (p *Period) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*p = Period{}
+		return nil
+	case time.Time:
+		*p = PeriodOf(value)
+		return nil
+	case []byte:
+		return p.scanString(string(value))
+	case string:
+		return p.scanString(value)
+	}
+
+	return fmt.Errorf("cannot scan %T into Period", src)
+}
+
+func (p *Period) scanString(value string) error {
+	if len(value) >= 10 {
+		t, err := time.Parse("2006-01-02", value[:10])
+		if err == nil {
+			*p = PeriodOf(t)
+			return nil
+		}
+	}
+
+	period, err := ParsePeriod(value)
+	if err != nil {
+		return err
+	}
+
+	*p = period
+	return nil
+}
+
+// Value implements driver.Valuer, storing the first day of the month.
+func (p Period) Value() (driver.Value, error) {
+	if p.IsZero() {
+		return nil, nil
+	}
+	return p.Time(), nil
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Period
+		wantErr bool
+	}{
+		{"January 2024", Period{2024, time.January}, false},
+		{"january  2024 ", Period{2024, time.January}, false},
+		{"Jan 2024", Period{2024, time.January}, false},
+		{"Sept. 2023", Period{2023, time.September}, false},
+		{"March, 2024", Period{2024, time.March}, false},
+		{"2024-01", Period{2024, time.January}, false},
+		{"2024/1", Period{2024, time.January}, false},
+		{"01/2024", Period{2024, time.January}, false},
+		{"12-2023", Period{2023, time.December}, false},
+		{"2024-13", Period{}, true},
+		{"Ja 2024", Period{}, true},
+		{"Smarch 2024", Period{}, true},
+		{"", Period{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePeriod(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParsePeriod(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("ParsePeriod(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPeriodFormatting(t *testing.T) {
+	period := Period{2024, time.February}
+
+	if got := period.String(); got != "2024-02" {
+		t.Fatalf("String() = %q", got)
+	}
+	if got := period.Label(); got != "February 2024" {
+		t.Fatalf("Label() = %q", got)
+	}
+	if got := period.AddMonths(-2); got != (Period{2023, time.December}) {
+		t.Fatalf("AddMonths(-2) = %v", got)
+	}
+}
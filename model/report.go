@@ -1,91 +1,142 @@
 package model
 
 import (
-	"math"
+	"encoding/json"
 	"time"
 )
 
 type Report struct {
-	Id                              int       `json:"id"`
-	MonthOf                         string    `json:"month_of"`
-	WorkerName                      string    `json:"worker_name"`
-	AreaOfAssignment                string    `json:"area_of_assignment"`
-	NameOfChurch                    string    `json:"name_of_church"`
-	WorshipService                  []int     `json:"worship_service,omitempty"`
-	SundaySchool                    []int     `json:"sunday_school,omitempty"`
-	PrayerMeetings                  []int     `json:"prayer_meetings,omitempty"`
-	BibleStudies                    []int     `json:"bible_studies,omitempty"`
-	MensFellowships                 []int     `json:"mens_fellowships,omitempty"`
-	WomensFellowships               []int     `json:"womens_fellowships,omitempty"`
-	YouthFellowships                []int     `json:"youth_fellowships,omitempty"`
-	ChildFellowships                []int     `json:"child_fellowships,omitempty"`
-	Outreach                        []int     `json:"outreach,omitempty"`
-	TrainingOrSeminars              []int     `json:"training_or_seminars,omitempty"`
-	LeadershipConferences           []int     `json:"leadership_conferences,omitempty"`
-	LeadershipTraining              []int     `json:"leadership_training,omitempty"`
-	Others                          []int     `json:"others,omitempty"`
-	FamilyDays                      []int     `json:"family_days,omitempty"`
-	TithesAndOfferings              []int     `json:"tithes_and_offerings,omitempty"`
-	WorshipServiceAvg               float64   `json:"worship_service_average,omitempty"`
-	SundaySchoolAvg                 float64   `json:"sunday_school_average,omitempty"`
-	PrayerMeetingsAvg               float64   `json:"prayer_meetings_average,omitempty"`
-	BibleStudiesAvg                 float64   `json:"bible_studies_average,omitempty"`
-	MensFellowshipsAvg              float64   `json:"mens_fellowships_average,omitempty"`
-	WomensFellowshipsAvg            float64   `json:"womens_fellowships_average,omitempty"`
-	YouthFellowshipsAvg             float64   `json:"youth_fellowships_average,omitempty"`
-	ChildFellowshipsAvg             float64   `json:"child_fellowships_average,omitempty"`
-	OutreachAvg                     float64   `json:"outreach_average,omitempty"`
-	TrainingOrSeminarsAvg           float64   `json:"training_or_seminars_average,omitempty"`
-	LeadershipConferencesAvg        float64   `json:"leadership_conferences_average,omitempty"`
-	LeadershipTrainingAvg           float64   `json:"leadership_training_average,omitempty"`
-	OthersAvg                       float64   `json:"others_average,omitempty"`
-	FamilyDaysAvg                   float64   `json:"family_days_average,omitempty"`
-	TithesAndOfferingsAvg           float64   `json:"tithes_and_offerings_average,omitempty"`
-	HomeVisited                     []int     `json:"home_visited,omitempty"`
-	BibleStudyOrGroupLed            []int     `json:"bible_study_or_group_led,omitempty"`
-	SermonOrMessagePreached         []int     `json:"sermon_or_message_preached,omitempty"`
-	PersonNewlyContacted            []int     `json:"person_newly_contacted,omitempty"`
-	PersonFollowedUp                []int     `json:"person_followed_up,omitempty"`
-	PersonLedToChrist               []int     `json:"person_led_to_christ,omitempty"`
-	Names                           []string  `json:"names,omitempty"`
-	HomeVisitedAvg                  float64   `json:"home_visited_average,omitempty"`
-	BibleStudyOrGroupLedAvg         float64   `json:"bible_study_or_group_led_average,omitempty"`
-	SermonOrMessagePreachedAvg      float64   `json:"sermon_or_message_preached_average,omitempty"`
-	PersonNewlyContactedAvg         float64   `json:"person_newly_contacted_average,omitempty"`
-	PersonFollowedUpAvg             float64   `json:"person_followed_up_average,omitempty"`
-	PersonLedToChristAvg            float64   `json:"person_led_to_christ_average,omitempty"`
-	NarrativeReport                 string    `json:"narrative_report"`
-	ChallengesAndProblemEncountered string    `json:"challenges_and_problem_encountered"`
-	PrayerRequest                   string    `json:"prayer_request"`
-	CreatedAt                       time.Time `json:"created_at"`
-	UpdatedAt                       time.Time `json:"updated_at"`
+	Id                              int            `json:"id"`
+	MonthOf                         Period         `json:"month_of"`
+	WorkerId                        int            `json:"worker_id"`
+	WorkerName                      string         `json:"worker_name"`
+	AreaId                          int            `json:"area_id"`
+	AreaOfAssignment                string         `json:"area_of_assignment"`
+	ChurchId                        int            `json:"church_id"`
+	NameOfChurch                    string         `json:"name_of_church"`
+	Activities                      ActivityValues `json:"activities"`
+	Stats                           ReportStats    `json:"stats,omitempty"`
+	Names                           []string       `json:"names,omitempty"`
+	NarrativeReport                 string         `json:"narrative_report"`
+	ChallengesAndProblemEncountered string         `json:"challenges_and_problem_encountered"`
+	PrayerRequest                   string         `json:"prayer_request"`
+	Status                          string         `json:"status"`
+	ReviewComment                   string         `json:"review_comment,omitempty"`
+	SubmittedAt                     *time.Time     `json:"submitted_at,omitempty"`
+	ReviewedAt                      *time.Time     `json:"reviewed_at,omitempty"`
+	ReviewedBy                      int            `json:"reviewed_by,omitempty"`
+	DeletedAt                       *time.Time     `json:"deleted_at,omitempty"`
+	DeletedBy                       int            `json:"deleted_by,omitempty"`
+	CreatedAt                       time.Time      `json:"created_at"`
+	UpdatedAt                       time.Time      `json:"updated_at"`
+
+	// Search is only set on the results of a full-text search.
+	Search *ReportSearchMatch `json:"search,omitempty"`
+}
+
+// ReportSearchMatch tells how well a report matched a full-text search.
+// Highlights holds a snippet of every text section that matched, by its
+// JSON name, as HTML: the text is escaped and the matching words are
+// wrapped in <mark></mark>.
+type ReportSearchMatch struct {
+	Rank       float64           `json:"rank"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+// UnmarshalJSON also reads the activities of revisions saved before the
+// activity catalog, when they were top-level fields.
+func (report *Report) UnmarshalJSON(data []byte) error {
+	type plainReport Report
+	if err := json.Unmarshal(data, (*plainReport)(report)); err != nil {
+		return err
+	}
+	return MergeLegacyActivities(data, &report.Activities)
 }
 
 type SearchReportQuery struct {
 	MonthOf    string `schema:"month_of"`
 	WorkerName string `schema:"worker_name"`
+	WorkerId   int    `schema:"worker_id"`
+	AreaId     int    `schema:"area_id"`
+	ChurchId   int    `schema:"church_id"`
+	Status     string `schema:"status"`
 	Page       int    `schema:"page"`
 	PerPage    int    `schema:"per_page"`
+
+	// After pages by id instead of page number: only reports with a higher
+	// id are listed, in id order.
+	After int `schema:"after"`
+
+	// Search is a full-text query over the narrative, challenges and prayer
+	// requests, in web search syntax: words, "quoted phrases", or, -word.
+	Search string `schema:"q"`
+
+	// MonthFrom and MonthTo bound the month of the report, both included.
+	MonthFrom Period `schema:"month_from"`
+	MonthTo   Period `schema:"month_to"`
+
+	// Created and updated ranges run from the start of the From day to the
+	// end of the To day, Manila time. Zero times leave a side open.
+	CreatedFrom time.Time `schema:"created_from"`
+	CreatedTo   time.Time `schema:"created_to"`
+	UpdatedFrom time.Time `schema:"updated_from"`
+	UpdatedTo   time.Time `schema:"updated_to"`
+
+	Metrics []MetricFilter `schema:"-"`
+	Sort    []ReportSort   `schema:"-"`
+
+	// Fields limits the listed reports to these JSON fields, see
+	// ParseReportFields. Empty lists every field.
+	Fields []string `schema:"-"`
+
+	// Set by the service from the caller's role, never from the request.
+	ScopeWorkerId int `schema:"-"`
+	ScopeAreaId   int `schema:"-"`
+
+	// Deleted lists the trash instead of the live reports.
+	Deleted bool `schema:"-"`
+
+	// SubmittedOnly leaves out drafts and returned reports when Status is
+	// empty, the way totals and analytics count.
+	SubmittedOnly bool `schema:"-"`
 }
 
 type SearchReportResult struct {
 	TotalCount int       `json:"total_count"`
+	TotalPages int       `json:"total_pages"`
+	HasNext    bool      `json:"has_next"`
 	Reports    []*Report `json:"reports"`
 	Page       int       `json:"page"`
 	PerPage    int       `json:"per_page"`
+
+	// Set when paging with After; NextAfter is the cursor of the next page.
+	After     int `json:"after,omitempty"`
+	NextAfter int `json:"next_after,omitempty"`
+
+	// Fields echoes SearchReportQuery.Fields; the reports are then encoded
+	// with only these fields and the id.
+	Fields []string `json:"fields,omitempty"`
 }
 
-// Helper function to calculate average attendance
-func CalculateAverage(attendance []int) float64 {
-	if len(attendance) == 0 {
-		return 0
+// MarshalJSON leaves out the report fields that were not selected, so
+// zero values of unread columns never reach the client.
+func (r SearchReportResult) MarshalJSON() ([]byte, error) {
+	type plainResult SearchReportResult
+	if len(r.Fields) == 0 {
+		return json.Marshal(plainResult(r))
 	}
 
-	total := 0
-	for _, a := range attendance {
-		total += a
+	reports := make([]map[string]json.RawMessage, len(r.Reports))
+	for i, report := range r.Reports {
+		projected, err := ProjectReport(report, r.Fields)
+		if err != nil {
+			return nil, err
+		}
+		reports[i] = projected
 	}
 
-	average := float64(total) / float64(len(attendance))
-	return math.Round(average)
+	return json.Marshal(struct {
+		plainResult
+		Reports []map[string]json.RawMessage `json:"reports"`
+	}{plainResult(r), reports})
 }
@@ -0,0 +1,37 @@
+package model
+
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionSkip   = "skip"
+)
+
+// ImportError points at the part of an uploaded file that could not be
+// imported: a sheet and cell for workbooks, a line and column for CSV.
+type ImportError struct {
+	Sheet   string `json:"sheet,omitempty"`
+	Cell    string `json:"cell,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportedReport is one report found in an uploaded file and what importing
+// it does, or did.
+type ImportedReport struct {
+	Sheet      string `json:"sheet,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Action     string `json:"action"`
+	ReportId   int    `json:"report_id,omitempty"`
+	WorkerName string `json:"worker_name"`
+	MonthOf    Period `json:"month_of"`
+}
+
+type ReportImportResult struct {
+	DryRun  bool              `json:"dry_run"`
+	Valid   bool              `json:"valid"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Reports []*ImportedReport `json:"reports"`
+	Errors  []ImportError     `json:"errors"`
+}
@@ -0,0 +1,241 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Measures of ActivityStats that reports can be filtered and sorted on.
+const (
+	MeasureTotal   = "total"
+	MeasureAverage = "average"
+	MeasureWeeks   = "weeks"
+)
+
+// ReportSortFields are the plain fields the report list can be sorted on.
+// Activity measures like worship_service_total can be used as well.
+var ReportSortFields = []string{
+	"id",
+	"month_of",
+	"worker_name",
+	"area_of_assignment",
+	"name_of_church",
+	"status",
+	"created_at",
+	"updated_at",
+	"submitted_at",
+}
+
+// MetricFilter keeps reports whose stats for Activity compare to Value,
+// e.g. person_led_to_christ_total>0.
+type MetricFilter struct {
+	Activity string
+	Measure  string
+	Operator string
+	Value    float64
+}
+
+var metricFilterPattern = regexp.MustCompile(`^([a-z][a-z0-9_]*)(>=|<=|!=|=|>|<)(-?[0-9]+(?:\.[0-9]+)?)$`)
+
+// ParseMetricFilter reads a condition like "worship_service_average>=20".
+func ParseMetricFilter(value string) (MetricFilter, error) {
+	match := metricFilterPattern.FindStringSubmatch(strings.ReplaceAll(value, " ", ""))
+	if match == nil {
+		return MetricFilter{}, fmt.Errorf("invalid metric filter %q, use e.g. worship_service_total>10", value)
+	}
+
+	activity, measure, ok := splitActivityMeasure(match[1])
+	if !ok {
+		return MetricFilter{}, fmt.Errorf("invalid metric %q, use <activity>_total, <activity>_average or <activity>_weeks", match[1])
+	}
+
+	number, err := strconv.ParseFloat(match[3], 64)
+	if err != nil {
+		return MetricFilter{}, fmt.Errorf("invalid metric value %q", match[3])
+	}
+
+	return MetricFilter{Activity: activity, Measure: measure, Operator: match[2], Value: number}, nil
+}
+
+// ReportSort is one key of the report list order. Activity and Measure are
+// set when sorting on an activity measure instead of Field.
+type ReportSort struct {
+	Field    string
+	Activity string
+	Measure  string
+	Desc     bool
+}
+
+// ParseReportSort reads a comma separated list of sort keys, each a field of
+// ReportSortFields or an activity measure, prefixed with "-" for descending
+// order or suffixed with ":desc" or ":asc".
+func ParseReportSort(value string) ([]ReportSort, error) {
+	var sorts []ReportSort
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var sort ReportSort
+		switch {
+		case strings.HasPrefix(part, "-"):
+			sort.Desc, part = true, part[1:]
+		case strings.HasSuffix(part, ":desc"):
+			sort.Desc, part = true, strings.TrimSuffix(part, ":desc")
+		case strings.HasSuffix(part, ":asc"):
+			part = strings.TrimSuffix(part, ":asc")
+		}
+
+		if isReportSortField(part) {
+			sort.Field = part
+		} else if activity, measure, ok := splitActivityMeasure(part); ok {
+			sort.Activity, sort.Measure = activity, measure
+		} else {
+			return nil, fmt.Errorf("cannot sort on %q", part)
+		}
+
+		sorts = append(sorts, sort)
+	}
+
+	return sorts, nil
+}
+
+func isReportSortField(field string) bool {
+	for _, name := range ReportSortFields {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// splitActivityMeasure splits "worship_service_total" into its activity key
+// and measure.
+func splitActivityMeasure(name string) (string, string, bool) {
+	index := strings.LastIndex(name, "_")
+	if index < 0 {
+		return "", "", false
+	}
+
+	activity, measure := name[:index], name[index+1:]
+	switch measure {
+	case MeasureTotal, MeasureAverage, MeasureWeeks:
+	default:
+		return "", "", false
+	}
+
+	return activity, measure, IsValidActivityKey(activity)
+}
+
+// ReportFields are the JSON fields of Report a list can be narrowed to.
+var ReportFields = []string{
+	"id",
+	"month_of",
+	"worker_id",
+	"worker_name",
+	"area_id",
+	"area_of_assignment",
+	"church_id",
+	"name_of_church",
+	"created_at",
+	"updated_at",
+	"status",
+	"review_comment",
+	"submitted_at",
+	"reviewed_at",
+	"reviewed_by",
+	"deleted_at",
+	"deleted_by",
+	"activities",
+	"names",
+	"narrative_report",
+	"challenges_and_problem_encountered",
+	"prayer_request",
+	"stats",
+}
+
+// ReportSummaryFields is the "summary" projection: what the report list
+// shows, without activities, stats or the text sections.
+var ReportSummaryFields = []string{
+	"id",
+	"month_of",
+	"worker_id",
+	"worker_name",
+	"area_id",
+	"area_of_assignment",
+	"church_id",
+	"name_of_church",
+	"status",
+	"submitted_at",
+	"created_at",
+	"updated_at",
+}
+
+// ParseReportFields reads a comma separated list of ReportFields, where
+// "summary" stands for ReportSummaryFields. The id is always included.
+func ParseReportFields(value string) ([]string, error) {
+	fields := []string{"id"}
+	seen := map[string]bool{"id": true}
+	add := func(field string) {
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+		case part == "summary":
+			for _, field := range ReportSummaryFields {
+				add(field)
+			}
+		case isReportField(part):
+			add(part)
+		default:
+			return nil, fmt.Errorf("unknown field %q", part)
+		}
+	}
+
+	return fields, nil
+}
+
+func isReportField(field string) bool {
+	for _, name := range ReportFields {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectReport encodes report as a JSON object holding only fields, plus
+// the search match of a full-text search.
+func ProjectReport(report *Report, fields []string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	projected := make(map[string]json.RawMessage, len(fields)+1)
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			projected[field] = value
+		}
+	}
+	if value, ok := all["search"]; ok {
+		projected["search"] = value
+	}
+	return projected, nil
+}
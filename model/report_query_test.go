@@ -0,0 +1,83 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseMetricFilter(t *testing.T) {
+	metric, err := ParseMetricFilter("person_led_to_christ_total>0")
+	if err != nil {
+		t.Fatalf("ParseMetricFilter() error = %v", err)
+	}
+	if want := (MetricFilter{Activity: "person_led_to_christ", Measure: MeasureTotal, Operator: ">", Value: 0}); metric != want {
+		t.Fatalf("ParseMetricFilter() = %+v, want %+v", metric, want)
+	}
+
+	for _, value := range []string{"worship_service>1", "worship_service_total~1", "worship_service_total>x", "Drop_total>1"} {
+		if _, err := ParseMetricFilter(value); err == nil {
+			t.Fatalf("ParseMetricFilter(%q) error = nil, want an error", value)
+		}
+	}
+}
+
+func TestParseReportSort(t *testing.T) {
+	sorts, err := ParseReportSort("month_of:desc, worker_name,-worship_service_average")
+	if err != nil {
+		t.Fatalf("ParseReportSort() error = %v", err)
+	}
+
+	want := []ReportSort{
+		{Field: "month_of", Desc: true},
+		{Field: "worker_name"},
+		{Activity: "worship_service", Measure: MeasureAverage, Desc: true},
+	}
+	if !reflect.DeepEqual(sorts, want) {
+		t.Fatalf("ParseReportSort() = %+v, want %+v", sorts, want)
+	}
+
+	if _, err := ParseReportSort("id;DROP TABLE reports"); err == nil {
+		t.Fatal("ParseReportSort() accepted an unknown field")
+	}
+}
+
+func TestParseReportFields(t *testing.T) {
+	fields, err := ParseReportFields("status,summary,stats")
+	if err != nil {
+		t.Fatalf("ParseReportFields() error = %v", err)
+	}
+	if fields[0] != "id" || fields[1] != "status" || fields[len(fields)-1] != "stats" {
+		t.Fatalf("ParseReportFields() = %v", fields)
+	}
+	if len(fields) != len(ReportSummaryFields)+1 {
+		t.Fatalf("ParseReportFields() = %v, want each field once", fields)
+	}
+
+	if _, err := ParseReportFields("id,password"); err == nil {
+		t.Fatal("ParseReportFields() accepted an unknown field")
+	}
+}
+
+func TestSearchReportResultFields(t *testing.T) {
+	result := SearchReportResult{
+		Reports: []*Report{{Id: 4, WorkerName: "Ana", NarrativeReport: "unread"}},
+		Fields:  []string{"id", "worker_name"},
+	}
+
+	data, err := json.Marshal(&result)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded struct {
+		Reports []map[string]interface{} `json:"reports"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := map[string]interface{}{"id": 4.0, "worker_name": "Ana"}
+	if !reflect.DeepEqual(decoded.Reports[0], want) {
+		t.Fatalf("report = %v, want %v", decoded.Reports[0], want)
+	}
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+const (
+	RevisionActionCreate  = "create"
+	RevisionActionUpdate  = "update"
+	RevisionActionDelete  = "delete"
+	RevisionActionSubmit  = "submit"
+	RevisionActionApprove = "approve"
+	RevisionActionReturn  = "return"
+	RevisionActionRestore = "restore" // content rolled back to an old revision
+
+	// RevisionActionUndelete takes a report out of the trash.
+	RevisionActionUndelete = "undelete"
+)
+
+type ReportRevision struct {
+	Id            int           `json:"id"`
+	ReportId      int           `json:"report_id"`
+	Revision      int           `json:"revision"`
+	Action        string        `json:"action"`
+	ActorId       int           `json:"actor_id,omitempty"`
+	ActorUsername string        `json:"actor_username,omitempty"`
+	Snapshot      *Report       `json:"snapshot,omitempty"`
+	Changes       []FieldChange `json:"changes"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// FieldChange is a single field that differs between two revisions, with the
+// JSON value before and after the change.
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// ignoredDiffFields are bookkeeping or derived fields that change on every
+// write and would only add noise to the history.
+var ignoredDiffFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+	"stats":      true,
+}
+
+// DiffReports lists the fields that differ between before and after, by
+// their JSON name. Activities are compared one by one, as
+// "activities.worship_service". A nil before or after is treated as an
+// empty report.
+func DiffReports(before, after *Report) ([]FieldChange, error) {
+	beforeFields, err := reportFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := reportFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for name := range beforeFields {
+		names[name] = true
+	}
+	for name := range afterFields {
+		names[name] = true
+	}
+
+	changes := []FieldChange{}
+	for name := range names {
+		if ignoredDiffFields[name] {
+			continue
+		}
+
+		oldValue, newValue := beforeFields[name], afterFields[name]
+		if string(oldValue) == string(newValue) {
+			continue
+		}
+
+		changes = append(changes, FieldChange{Field: name, Old: nullIfEmpty(oldValue), New: nullIfEmpty(newValue)})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+func reportFields(report *Report) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if report == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if raw, ok := fields["activities"]; ok {
+		delete(fields, "activities")
+
+		var activities map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &activities); err != nil {
+			return nil, err
+		}
+		for key, values := range activities {
+			fields["activities."+key] = values
+		}
+	}
+
+	return fields, nil
+}
+
+func nullIfEmpty(value json.RawMessage) json.RawMessage {
+	if len(value) == 0 {
+		return json.RawMessage("null")
+	}
+	return value
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffReports(t *testing.T) {
+	before := &Report{
+		Id:              7,
+		MonthOf:         Period{Year: 2024, Month: time.January},
+		WorkerId:        3,
+		Activities:      ActivityValues{"worship_service": {10, 12}},
+		NarrativeReport: "First draft",
+		UpdatedAt:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	after := *before
+	after.Activities = ActivityValues{"worship_service": {10, 15}}
+	after.NarrativeReport = "Second draft"
+	after.UpdatedAt = time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	changes, err := DiffReports(before, &after)
+	if err != nil {
+		t.Fatalf("DiffReports: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2: %+v", len(changes), changes)
+	}
+
+	if changes[0].Field != "activities.worship_service" || string(changes[0].Old) != `[10,12]` || string(changes[0].New) != `[10,15]` {
+		t.Errorf("unexpected change %s: %s -> %s", changes[0].Field, changes[0].Old, changes[0].New)
+	}
+
+	if changes[1].Field != "narrative_report" || string(changes[1].Old) != `"First draft"` || string(changes[1].New) != `"Second draft"` {
+		t.Errorf("unexpected change %s: %s -> %s", changes[1].Field, changes[1].Old, changes[1].New)
+	}
+}
+
+func TestDiffReportsFromNothing(t *testing.T) {
+	changes, err := DiffReports(nil, &Report{WorkerId: 3})
+	if err != nil {
+		t.Fatalf("DiffReports: %v", err)
+	}
+
+	for _, change := range changes {
+		if string(change.Old) != "null" {
+			t.Errorf("%s: old value = %s, want null", change.Field, change.Old)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// RoundingMode says how averages are rounded to their precision.
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "half_up"
+	RoundHalfEven RoundingMode = "half_even"
+	RoundDown     RoundingMode = "down"
+	RoundUp       RoundingMode = "up"
+)
+
+// AverageRounding rounds activity averages to Precision decimals.
+type AverageRounding struct {
+	Mode      RoundingMode
+	Precision int
+}
+
+// DefaultAverageRounding matches the two decimals printed on the exports.
+var DefaultAverageRounding = AverageRounding{Mode: RoundHalfUp, Precision: 2}
+
+// ParseAverageRounding checks a rounding mode and precision from the config.
+// An empty mode means half up.
+func ParseAverageRounding(mode string, precision int) (AverageRounding, error) {
+	rounding := AverageRounding{Mode: RoundingMode(mode), Precision: precision}
+	if rounding.Mode == "" {
+		rounding.Mode = RoundHalfUp
+	}
+
+	switch rounding.Mode {
+	case RoundHalfUp, RoundHalfEven, RoundDown, RoundUp:
+	default:
+		return rounding, fmt.Errorf("unknown rounding mode %q, use half_up, half_even, down or up", mode)
+	}
+
+	if precision < 0 || precision > 6 {
+		return rounding, fmt.Errorf("average precision must be between 0 and 6, got %d", precision)
+	}
+
+	return rounding, nil
+}
+
+// Round rounds value to the configured number of decimals.
+func (r AverageRounding) Round(value float64) float64 {
+	scale := math.Pow10(r.Precision)
+
+	// Snap away float noise so 17.335 is treated as exactly half
+	scaled := math.Round(value*scale*1e6) / 1e6
+
+	switch r.Mode {
+	case RoundHalfEven:
+		scaled = math.RoundToEven(scaled)
+	case RoundDown:
+		scaled = math.Trunc(scaled)
+	case RoundUp:
+		scaled = math.Ceil(scaled)
+	default:
+		scaled = math.Round(scaled)
+	}
+
+	return scaled / scale
+}
+
+// ActivityStats sums up the weekly values of one activity.
+type ActivityStats struct {
+	Total   int     `json:"total"`
+	Weeks   int     `json:"weeks"`
+	Average float64 `json:"average"`
+}
+
+// ReportStats holds the stats of every activity of a report by its JSON
+// name. It is stored as JSONB so reports can be filtered and sorted on it.
+type ReportStats map[string]ActivityStats
+
+func (s ReportStats) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(s)
+}
+
+func (s *ReportStats) Scan(src interface{}) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+		*s = ReportStats{}
+		return nil
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("cannot scan %T into ReportStats", src)
+	}
+
+	stats := ReportStats{}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return err
+	}
+	*s = stats
+	return nil
+}
+
+// ComputeStats works out the total and rounded average of every activity
+// of report and stores them in Stats.
+func (report *Report) ComputeStats(rounding AverageRounding) {
+	report.Stats = ReportStats{}
+
+	for key, values := range report.Activities {
+		stats := ActivityStats{Weeks: len(values)}
+		for _, value := range values {
+			stats.Total += value
+		}
+		if stats.Weeks > 0 {
+			stats.Average = rounding.Round(float64(stats.Total) / float64(stats.Weeks))
+		}
+
+		report.Stats[key] = stats
+	}
+}
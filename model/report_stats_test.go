@@ -0,0 +1,53 @@
+package model
+
+import "testing"
+
+func TestAverageRoundingRound(t *testing.T) {
+	tests := []struct {
+		rounding AverageRounding
+		value    float64
+		want     float64
+	}{
+		{AverageRounding{RoundHalfUp, 2}, 52.0 / 3, 17.33},
+		{AverageRounding{RoundHalfUp, 2}, 17.335, 17.34},
+		{AverageRounding{RoundHalfEven, 2}, 17.335, 17.34},
+		{AverageRounding{RoundHalfEven, 2}, 17.345, 17.34},
+		{AverageRounding{RoundDown, 2}, 53.0 / 3, 17.66},
+		{AverageRounding{RoundUp, 2}, 52.0 / 3, 17.34},
+		{AverageRounding{RoundHalfUp, 0}, 2.5, 3},
+		{AverageRounding{RoundHalfEven, 0}, 2.5, 2},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rounding.Round(tt.value); got != tt.want {
+			t.Fatalf("%+v.Round(%v) = %v, want %v", tt.rounding, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	report := Report{Activities: ActivityValues{"worship_service": {10, 20, 22}, "sunday_school": {}}}
+	report.ComputeStats(DefaultAverageRounding)
+
+	if got, want := report.Stats["worship_service"], (ActivityStats{Total: 52, Weeks: 3, Average: 17.33}); got != want {
+		t.Fatalf("worship service stats = %+v, want %+v", got, want)
+	}
+	if got := report.Stats["sunday_school"]; got != (ActivityStats{}) {
+		t.Fatalf("sunday school stats = %+v, want zero", got)
+	}
+	if len(report.Stats) != 2 {
+		t.Fatalf("stats has %d activities, want 2", len(report.Stats))
+	}
+}
+
+func TestParseAverageRounding(t *testing.T) {
+	if rounding, err := ParseAverageRounding("", 2); err != nil || rounding != DefaultAverageRounding {
+		t.Fatalf("ParseAverageRounding(\"\", 2) = %+v, %v", rounding, err)
+	}
+	if _, err := ParseAverageRounding("bankers", 2); err == nil {
+		t.Fatal("ParseAverageRounding(\"bankers\", 2) error = nil")
+	}
+	if _, err := ParseAverageRounding("down", -1); err == nil {
+		t.Fatal("ParseAverageRounding(\"down\", -1) error = nil")
+	}
+}
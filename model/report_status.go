@@ -0,0 +1,18 @@
+package model
+
+const (
+	ReportStatusDraft     = "draft"
+	ReportStatusSubmitted = "submitted"
+	ReportStatusApproved  = "approved"
+	ReportStatusReturned  = "returned"
+)
+
+// IsValidReportStatus reports whether status is one of the review workflow
+// states.
+func IsValidReportStatus(status string) bool {
+	switch status {
+	case ReportStatusDraft, ReportStatusSubmitted, ReportStatusApproved, ReportStatusReturned:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+const (
+	RoleWorker         = "worker"
+	RoleAreaSupervisor = "area_supervisor"
+	RoleNationalAdmin  = "national_admin"
+)
+
+type User struct {
+	Id        int       `json:"id"`
+	Username  string    `json:"username"`
+	Password  string    `json:"-"`
+	Role      string    `json:"role"`
+	WorkerId  int       `json:"worker_id,omitempty"`
+	AreaId    int       `json:"area_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsValidRole reports whether role is one of the known user roles.
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleWorker, RoleAreaSupervisor, RoleNationalAdmin:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type Worker struct {
+	Id         int       `json:"id"`
+	Name       string    `json:"name"`
+	AreaId     int       `json:"area_id,omitempty"`
+	AreaName   string    `json:"area_name,omitempty"`
+	ChurchId   int       `json:"church_id,omitempty"`
+	ChurchName string    `json:"church_name,omitempty"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
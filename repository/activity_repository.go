@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"reports/model"
+)
+
+type ActivityRepository interface {
+	Save(ctx context.Context, activity *model.Activity) error
+	Update(ctx context.Context, activity *model.Activity) error
+	FindById(ctx context.Context, activityId int) (*model.Activity, error)
+	FindAll(ctx context.Context) (model.ActivityCatalog, error)
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"reports/helper"
+	"reports/model"
+)
+
+type ActivityRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewActivityRepository(Db *sql.DB) ActivityRepository {
+	return &ActivityRepositoryImpl{Db: Db}
+}
+
+func (r *ActivityRepositoryImpl) Save(ctx context.Context, activity *model.Activity) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		INSERT INTO activities (
+			key,
+			label,
+			category,
+			display_order,
+			active,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	return tx.QueryRowContext(ctx, rawSQL,
+		activity.Key,
+		activity.Label,
+		activity.Category,
+		activity.DisplayOrder,
+		activity.Active,
+		activity.CreatedAt,
+		activity.UpdatedAt,
+	).Scan(&activity.Id)
+}
+
+// Update saves everything but the key, which reports refer to.
+func (r *ActivityRepositoryImpl) Update(ctx context.Context, activity *model.Activity) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		UPDATE activities SET
+			label = $1,
+			category = $2,
+			display_order = $3,
+			active = $4,
+			updated_at = $5
+		WHERE id = $6
+	`
+
+	_, err = tx.ExecContext(ctx, rawSQL,
+		activity.Label,
+		activity.Category,
+		activity.DisplayOrder,
+		activity.Active,
+		activity.UpdatedAt,
+		activity.Id,
+	)
+	return err
+}
+
+func (r *ActivityRepositoryImpl) FindById(ctx context.Context, activityId int) (*model.Activity, error) {
+	rawSQL := `
+		SELECT
+			id,
+			key,
+			label,
+			category,
+			display_order,
+			active,
+			created_at,
+			updated_at
+		FROM activities
+		WHERE id = $1
+	`
+
+	var activity model.Activity
+	err := r.Db.QueryRowContext(ctx, rawSQL, activityId).Scan(
+		&activity.Id,
+		&activity.Key,
+		&activity.Label,
+		&activity.Category,
+		&activity.DisplayOrder,
+		&activity.Active,
+		&activity.CreatedAt,
+		&activity.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &activity, nil
+}
+
+// FindAll lists every activity, inactive ones included, in display order.
+func (r *ActivityRepositoryImpl) FindAll(ctx context.Context) (model.ActivityCatalog, error) {
+	rawSQL := `
+		SELECT
+			id,
+			key,
+			label,
+			category,
+			display_order,
+			active,
+			created_at,
+			updated_at
+		FROM activities
+		ORDER BY display_order, id
+	`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	catalog := model.ActivityCatalog{}
+	for rows.Next() {
+		var activity model.Activity
+		if err := rows.Scan(
+			&activity.Id,
+			&activity.Key,
+			&activity.Label,
+			&activity.Category,
+			&activity.DisplayOrder,
+			&activity.Active,
+			&activity.CreatedAt,
+			&activity.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		catalog = append(catalog, &activity)
+	}
+
+	return catalog, rows.Err()
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"reports/model"
+)
+
+type AnalyticsRepository interface {
+	Summary(ctx context.Context, query *model.AnalyticsQuery) ([]*model.AnalyticsGroup, error)
+	Roster(ctx context.Context, areaId int, from, to model.Period) ([]*model.RosterWorker, error)
+	MonthlyActivity(ctx context.Context, filter *model.SearchReportQuery, activity string, from, to model.Period) ([]model.MonthlyActivity, error)
+}
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reports/model"
+	"strconv"
+	"strings"
+)
+
+type AnalyticsRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewAnalyticsRepository(Db *sql.DB) AnalyticsRepository {
+	return &AnalyticsRepositoryImpl{Db: Db}
+}
+
+// analyticsConditions filters the reports analytics add up. Without a
+// status filter only submitted and approved reports count; drafts and
+// returned reports are still being worked on.
+func analyticsConditions(filter *model.SearchReportQuery) ([]string, []interface{}) {
+	counted := *filter
+	counted.SubmittedOnly = true
+	return reportConditions(&counted)
+}
+
+// analyticsDimension is a column set the summary can be grouped by,
+// ordered by its name and then its id so namesakes stay apart.
+type analyticsDimension struct {
+	exprs   []string
+	order   string
+	targets func(group *model.AnalyticsGroup) []interface{}
+}
+
+var analyticsDimensions = map[string]analyticsDimension{
+	model.GroupByArea: {
+		exprs:   []string{"t.area_id", "a.name"},
+		order:   "a.name, t.area_id",
+		targets: func(g *model.AnalyticsGroup) []interface{} { return []interface{}{&g.AreaId, &g.AreaOfAssignment} },
+	},
+	model.GroupByChurch: {
+		exprs:   []string{"t.church_id", "c.name"},
+		order:   "c.name, t.church_id",
+		targets: func(g *model.AnalyticsGroup) []interface{} { return []interface{}{&g.ChurchId, &g.NameOfChurch} },
+	},
+	model.GroupByWorker: {
+		exprs:   []string{"t.worker_id", "w.name"},
+		order:   "w.name, t.worker_id",
+		targets: func(g *model.AnalyticsGroup) []interface{} { return []interface{}{&g.WorkerId, &g.WorkerName} },
+	},
+}
+
+// periodFormats formats month_of for each period granularity. The formats
+// sort in time order.
+var periodFormats = map[string]string{
+	model.PeriodMonth:   `YYYY-MM`,
+	model.PeriodQuarter: `YYYY-"Q"Q`,
+	model.PeriodYear:    `YYYY`,
+}
+
+// reportActivitiesSQL spreads the activities of a report into one row per
+// activity with the sum and number of its weekly values.
+const reportActivitiesSQL = `
+		LEFT JOIN LATERAL (
+			SELECT
+				e.key,
+				(SELECT COALESCE(SUM(v::numeric), 0) FROM jsonb_array_elements_text(e.value) v) AS total,
+				jsonb_array_length(e.value) AS weeks
+			FROM jsonb_each(t.activities) e
+			WHERE jsonb_typeof(e.value) = 'array'
+		) act ON true
+`
+
+// Summary adds up the weekly values of every activity over the reports
+// matching query.Filter, per combination of the query.GroupBy dimensions.
+// Each group row of the grouping sets is followed by its activity rows.
+func (r *AnalyticsRepositoryImpl) Summary(ctx context.Context, query *model.AnalyticsQuery) ([]*model.AnalyticsGroup, error) {
+	dimensions, err := analyticsGroupBy(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var exprs, order []string
+	for _, dimension := range dimensions {
+		exprs = append(exprs, dimension.exprs...)
+		order = append(order, dimension.order)
+	}
+
+	whereConditions, whereParams := analyticsConditions(&query.Filter)
+
+	var rawSQL strings.Builder
+	rawSQL.WriteString(`
+		SELECT
+			`)
+	for _, expr := range exprs {
+		rawSQL.WriteString(expr + ",\n\t\t\t")
+	}
+	rawSQL.WriteString(`GROUPING(act.key),
+			act.key,
+			COUNT(DISTINCT t.id),
+			COALESCE(SUM(act.total), 0)::bigint,
+			COALESCE(SUM(act.weeks), 0)::bigint` + reportsFromSQL + reportActivitiesSQL)
+
+	if len(whereConditions) > 0 {
+		rawSQL.WriteString(" WHERE ")
+		rawSQL.WriteString(strings.Join(whereConditions, " AND "))
+	}
+
+	withKey := append(append([]string{}, exprs...), "act.key")
+	rawSQL.WriteString(" GROUP BY GROUPING SETS ((" + strings.Join(withKey, ", ") + "), (" + strings.Join(exprs, ", ") + "))")
+	rawSQL.WriteString(" ORDER BY " + strings.Join(append(order, "GROUPING(act.key) DESC", "act.key"), ", "))
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL.String(), whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []*model.AnalyticsGroup{}
+	var group *model.AnalyticsGroup
+	for rows.Next() {
+		var row model.AnalyticsGroup
+		var groupRow int
+		var key sql.NullString
+		var reports, total, weeks int
+
+		var targets []interface{}
+		for _, dimension := range dimensions {
+			targets = append(targets, dimension.targets(&row)...)
+		}
+		targets = append(targets, &groupRow, &key, &reports, &total, &weeks)
+
+		if err := rows.Scan(targets...); err != nil {
+			return nil, err
+		}
+
+		if groupRow == 1 {
+			row.Reports = reports
+			row.Activities = map[string]*model.ActivitySummary{}
+			group = &row
+			groups = append(groups, group)
+			continue
+		}
+
+		// Reports without any activity have a NULL key
+		if group == nil || !key.Valid {
+			continue
+		}
+		group.Activities[key.String] = &model.ActivitySummary{Total: total, Weeks: weeks, Reports: reports}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// analyticsGroupBy looks up the dimensions of query.GroupBy, formatting the
+// period dimension with query.Period.
+func analyticsGroupBy(query *model.AnalyticsQuery) ([]analyticsDimension, error) {
+	var dimensions []analyticsDimension
+
+	for _, name := range query.GroupBy {
+		if name == model.GroupByPeriod {
+			format, ok := periodFormats[query.Period]
+			if !ok {
+				return nil, fmt.Errorf("invalid period %q", query.Period)
+			}
+			expr := "to_char(t.month_of, '" + format + "')"
+			dimensions = append(dimensions, analyticsDimension{
+				exprs:   []string{expr},
+				order:   expr,
+				targets: func(g *model.AnalyticsGroup) []interface{} { return []interface{}{&g.Period} },
+			})
+			continue
+		}
+
+		dimension, ok := analyticsDimensions[name]
+		if !ok {
+			return nil, fmt.Errorf("cannot group by %q", name)
+		}
+		dimensions = append(dimensions, dimension)
+	}
+
+	return dimensions, nil
+}
+
+// MonthlyActivity sums up activity over the reports matching filter for
+// every month from from to to. Months without reports are listed too, with
+// zero counts, so callers can tell them from months of zeros.
+func (r *AnalyticsRepositoryImpl) MonthlyActivity(ctx context.Context, filter *model.SearchReportQuery, activity string, from, to model.Period) ([]model.MonthlyActivity, error) {
+	monthFilter := *filter
+	monthFilter.MonthFrom, monthFilter.MonthTo = from, to
+
+	whereConditions, whereParams := analyticsConditions(&monthFilter)
+	where := ""
+	if len(whereConditions) > 0 {
+		where = " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	index := len(whereParams)
+	whereParams = append(whereParams, activity, from, to)
+	key := "t.activities -> $" + strconv.Itoa(index+1) + "::text"
+
+	rawSQL := `
+		SELECT
+			m.month::date,
+			COALESCE(s.reports, 0),
+			COALESCE(s.activity_reports, 0),
+			COALESCE(s.total, 0),
+			COALESCE(s.weeks, 0)
+		FROM generate_series($` + strconv.Itoa(index+2) + `::timestamp, $` + strconv.Itoa(index+3) + `::timestamp, interval '1 month') m(month)
+		LEFT JOIN (
+			SELECT
+				t.month_of,
+				COUNT(*) AS reports,
+				COUNT(act.weeks) AS activity_reports,
+				COALESCE(SUM(act.total), 0)::bigint AS total,
+				COALESCE(SUM(act.weeks), 0)::bigint AS weeks` + reportsFromSQL + `
+			LEFT JOIN LATERAL (
+				SELECT
+					(SELECT COALESCE(SUM(v::numeric), 0) FROM jsonb_array_elements_text(` + key + `) v) AS total,
+					jsonb_array_length(` + key + `) AS weeks
+				WHERE jsonb_typeof(` + key + `) = 'array'
+			) act ON true` + where + `
+			GROUP BY t.month_of
+		) s ON s.month_of = m.month::date
+		ORDER BY m.month
+	`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var months []model.MonthlyActivity
+	for rows.Next() {
+		var month model.MonthlyActivity
+		if err := rows.Scan(&month.Month, &month.Reports, &month.ActivityReports, &month.Total, &month.Weeks); err != nil {
+			return nil, err
+		}
+		months = append(months, month)
+	}
+
+	return months, rows.Err()
+}
+
+// Roster lists the active workers, of one area when areaId is set, with
+// their reports from from to to. Workers are ordered by area and name.
+func (r *AnalyticsRepositoryImpl) Roster(ctx context.Context, areaId int, from, to model.Period) ([]*model.RosterWorker, error) {
+	rawSQL := `
+		SELECT
+			w.id,
+			w.name,
+			COALESCE(w.area_id, 0),
+			COALESCE(a.name, ''),
+			COALESCE(w.church_id, 0),
+			COALESCE(c.name, ''),
+			w.active,
+			w.created_at,
+			w.updated_at,
+			t.month_of,
+			t.status,
+			t.submitted_at
+		FROM workers w
+		LEFT JOIN areas a ON a.id = w.area_id
+		LEFT JOIN churches c ON c.id = w.church_id
+		LEFT JOIN reports t ON t.worker_id = w.id
+			AND t.deleted_at IS NULL
+			AND t.month_of BETWEEN $2 AND $3
+		WHERE w.active AND ($1 = 0 OR w.area_id = $1)
+		ORDER BY a.name, w.area_id, w.name, w.id, t.month_of
+	`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL, areaId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roster := []*model.RosterWorker{}
+	var current *model.RosterWorker
+	for rows.Next() {
+		var worker model.RosterWorker
+		var month model.Period
+		var status sql.NullString
+		var submittedAt sql.NullTime
+
+		if err := rows.Scan(
+			&worker.Id,
+			&worker.Name,
+			&worker.AreaId,
+			&worker.AreaName,
+			&worker.ChurchId,
+			&worker.ChurchName,
+			&worker.Active,
+			&worker.CreatedAt,
+			&worker.UpdatedAt,
+			&month,
+			&status,
+			&submittedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if current == nil || current.Id != worker.Id {
+			current = &worker
+			roster = append(roster, current)
+		}
+
+		// Workers without reports in the range come with a NULL month
+		if month.IsZero() {
+			continue
+		}
+
+		submission := model.Submission{Month: month, Status: status.String}
+		if submittedAt.Valid {
+			submission.SubmittedAt = &submittedAt.Time
+		}
+		current.Reports = append(current.Reports, submission)
+	}
+
+	return roster, rows.Err()
+}
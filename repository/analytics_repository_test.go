@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"reports/model"
+	"testing"
+)
+
+func TestAnalyticsConditionsDefaultStatus(t *testing.T) {
+	submitted := "t.status IN ('submitted', 'approved')"
+
+	conditions, params := analyticsConditions(&model.SearchReportQuery{})
+	if !containsCondition(conditions, submitted) || len(params) != 0 {
+		t.Fatalf("conditions = %v, params = %v, want only submitted and approved reports", conditions, params)
+	}
+
+	conditions, params = analyticsConditions(&model.SearchReportQuery{Status: model.ReportStatusDraft})
+	if containsCondition(conditions, submitted) || len(params) != 1 || params[0] != model.ReportStatusDraft {
+		t.Fatalf("conditions = %v, params = %v, want the asked status only", conditions, params)
+	}
+}
+
+func containsCondition(conditions []string, condition string) bool {
+	for _, c := range conditions {
+		if c == condition {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"reports/helper"
+	"reports/model"
+)
+
+type AreaRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewAreaRepository(Db *sql.DB) AreaRepository {
+	return &AreaRepositoryImpl{Db: Db}
+}
+
+func (r *AreaRepositoryImpl) Save(ctx context.Context, area *model.Area) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		INSERT INTO areas (
+			name,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	return tx.QueryRowContext(ctx, rawSQL, area.Name, area.CreatedAt, area.UpdatedAt).Scan(&area.Id)
+}
+
+func (r *AreaRepositoryImpl) Update(ctx context.Context, area *model.Area) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		UPDATE areas SET
+			name = $1,
+			updated_at = $2
+		WHERE id = $3
+	`
+
+	_, err = tx.ExecContext(ctx, rawSQL, area.Name, area.UpdatedAt, area.Id)
+	return err
+}
+
+func (r *AreaRepositoryImpl) Delete(ctx context.Context, areaId int) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		DELETE FROM areas
+		WHERE id = $1
+	`
+
+	_, err = tx.ExecContext(ctx, rawSQL, areaId)
+	return err
+}
+
+func (r *AreaRepositoryImpl) FindById(ctx context.Context, areaId int) (*model.Area, error) {
+	rawSQL := `
+		SELECT
+			id,
+			name,
+			created_at,
+			updated_at
+		FROM areas
+		WHERE id = $1
+	`
+
+	var area model.Area
+	err := r.Db.QueryRowContext(ctx, rawSQL, areaId).Scan(
+		&area.Id,
+		&area.Name,
+		&area.CreatedAt,
+		&area.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &area, nil
+}
+
+// FindByName finds an area by name, ignoring case.
+func (r *AreaRepositoryImpl) FindByName(ctx context.Context, name string) (*model.Area, error) {
+	rawSQL := `
+		SELECT
+			id,
+			name,
+			created_at,
+			updated_at
+		FROM areas
+		WHERE LOWER(name) = LOWER($1)
+	`
+
+	var area model.Area
+	err := r.Db.QueryRowContext(ctx, rawSQL, name).Scan(
+		&area.Id,
+		&area.Name,
+		&area.CreatedAt,
+		&area.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &area, nil
+}
+
+func (r *AreaRepositoryImpl) FindAll(ctx context.Context) ([]*model.Area, error) {
+	rawSQL := `
+		SELECT
+			id,
+			name,
+			created_at,
+			updated_at
+		FROM areas
+		ORDER BY name
+	`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	areas := []*model.Area{}
+	for rows.Next() {
+		var area model.Area
+		if err := rows.Scan(
+			&area.Id,
+			&area.Name,
+			&area.CreatedAt,
+			&area.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		areas = append(areas, &area)
+	}
+
+	return areas, rows.Err()
+}
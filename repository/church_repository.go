@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"reports/model"
+)
+
+type ChurchRepository interface {
+	Save(ctx context.Context, church *model.Church) error
+	Update(ctx context.Context, church *model.Church) error
+	Delete(ctx context.Context, churchId int) error
+	FindById(ctx context.Context, churchId int) (*model.Church, error)
+	FindByName(ctx context.Context, areaId int, name string) (*model.Church, error)
+	FindAll(ctx context.Context, areaId int) ([]*model.Church, error)
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"reports/helper"
+	"reports/model"
+)
+
+type ChurchRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewChurchRepository(Db *sql.DB) ChurchRepository {
+	return &ChurchRepositoryImpl{Db: Db}
+}
+
+func (r *ChurchRepositoryImpl) Save(ctx context.Context, church *model.Church) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		INSERT INTO churches (
+			name,
+			area_id,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	return tx.QueryRowContext(ctx, rawSQL, church.Name, church.AreaId, church.CreatedAt, church.UpdatedAt).Scan(&church.Id)
+}
+
+func (r *ChurchRepositoryImpl) Update(ctx context.Context, church *model.Church) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		UPDATE churches SET
+			name = $1,
+			area_id = $2,
+			updated_at = $3
+		WHERE id = $4
+	`
+
+	_, err = tx.ExecContext(ctx, rawSQL, church.Name, church.AreaId, church.UpdatedAt, church.Id)
+	return err
+}
+
+func (r *ChurchRepositoryImpl) Delete(ctx context.Context, churchId int) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		DELETE FROM churches
+		WHERE id = $1
+	`
+
+	_, err = tx.ExecContext(ctx, rawSQL, churchId)
+	return err
+}
+
+func (r *ChurchRepositoryImpl) FindById(ctx context.Context, churchId int) (*model.Church, error) {
+	rawSQL := `
+		SELECT
+			c.id,
+			c.name,
+			c.area_id,
+			a.name,
+			c.created_at,
+			c.updated_at
+		FROM churches c
+		JOIN areas a ON a.id = c.area_id
+		WHERE c.id = $1
+	`
+
+	var church model.Church
+	err := r.Db.QueryRowContext(ctx, rawSQL, churchId).Scan(
+		&church.Id,
+		&church.Name,
+		&church.AreaId,
+		&church.AreaName,
+		&church.CreatedAt,
+		&church.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &church, nil
+}
+
+// FindByName finds a church of an area by name, ignoring case.
+func (r *ChurchRepositoryImpl) FindByName(ctx context.Context, areaId int, name string) (*model.Church, error) {
+	rawSQL := `
+		SELECT
+			c.id,
+			c.name,
+			c.area_id,
+			a.name,
+			c.created_at,
+			c.updated_at
+		FROM churches c
+		JOIN areas a ON a.id = c.area_id
+		WHERE c.area_id = $1
+			AND LOWER(c.name) = LOWER($2)
+	`
+
+	var church model.Church
+	err := r.Db.QueryRowContext(ctx, rawSQL, areaId, name).Scan(
+		&church.Id,
+		&church.Name,
+		&church.AreaId,
+		&church.AreaName,
+		&church.CreatedAt,
+		&church.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &church, nil
+}
+
+// FindAll lists churches, limited to one area when areaId is set.
+func (r *ChurchRepositoryImpl) FindAll(ctx context.Context, areaId int) ([]*model.Church, error) {
+	rawSQL := `
+		SELECT
+			c.id,
+			c.name,
+			c.area_id,
+			a.name,
+			c.created_at,
+			c.updated_at
+		FROM churches c
+		JOIN areas a ON a.id = c.area_id
+		WHERE $1 = 0 OR c.area_id = $1
+		ORDER BY a.name, c.name
+	`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL, areaId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	churches := []*model.Church{}
+	for rows.Next() {
+		var church model.Church
+		if err := rows.Scan(
+			&church.Id,
+			&church.Name,
+			&church.AreaId,
+			&church.AreaName,
+			&church.CreatedAt,
+			&church.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		churches = append(churches, &church)
+	}
+
+	return churches, rows.Err()
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"reports/model"
+	"strconv"
+	"strings"
+)
+
+// reportColumn ties an expression of selectReportsSQL to the field of
+// model.Report it is scanned into. key is the JSON name of that field, used
+// to pick the columns of a narrower SELECT. Columns with a name are written
+// back: all of them by Save, the editable ones by Update. JSONB columns go
+// through jsonColumn.
+type reportColumn struct {
+	expr     string
+	key      string
+	name     string
+	editable bool
+	jsonb    bool
+	field    func(report *model.Report) interface{}
+}
+
+// reportColumns lists the columns of reports in the order they are
+// selected. Adding a column to reports only takes a line here.
+var reportColumns = []reportColumn{
+	{expr: "t.id", key: "id", field: func(r *model.Report) interface{} { return &r.Id }},
+	{expr: "t.month_of", key: "month_of", name: "month_of", editable: true, field: func(r *model.Report) interface{} { return &r.MonthOf }},
+	{expr: "t.worker_id", key: "worker_id", name: "worker_id", editable: true, field: func(r *model.Report) interface{} { return &r.WorkerId }},
+	{expr: "w.name", key: "worker_name", field: func(r *model.Report) interface{} { return &r.WorkerName }},
+	{expr: "t.area_id", key: "area_id", name: "area_id", editable: true, field: func(r *model.Report) interface{} { return &r.AreaId }},
+	{expr: "a.name", key: "area_of_assignment", field: func(r *model.Report) interface{} { return &r.AreaOfAssignment }},
+	{expr: "t.church_id", key: "church_id", name: "church_id", editable: true, field: func(r *model.Report) interface{} { return &r.ChurchId }},
+	{expr: "c.name", key: "name_of_church", field: func(r *model.Report) interface{} { return &r.NameOfChurch }},
+	{expr: "t.created_at", key: "created_at", name: "created_at", field: func(r *model.Report) interface{} { return &r.CreatedAt }},
+	{expr: "t.updated_at", key: "updated_at", name: "updated_at", editable: true, field: func(r *model.Report) interface{} { return &r.UpdatedAt }},
+	{expr: "t.status", key: "status", name: "status", field: func(r *model.Report) interface{} { return &r.Status }},
+	{expr: "t.review_comment", key: "review_comment", field: func(r *model.Report) interface{} { return &r.ReviewComment }},
+	{expr: "t.submitted_at", key: "submitted_at", name: "submitted_at", field: func(r *model.Report) interface{} { return &r.SubmittedAt }},
+	{expr: "t.reviewed_at", key: "reviewed_at", field: func(r *model.Report) interface{} { return &r.ReviewedAt }},
+	{expr: "COALESCE(t.reviewed_by, 0)", key: "reviewed_by", field: func(r *model.Report) interface{} { return &r.ReviewedBy }},
+	{expr: "t.deleted_at", key: "deleted_at", field: func(r *model.Report) interface{} { return &r.DeletedAt }},
+	{expr: "COALESCE(t.deleted_by, 0)", key: "deleted_by", field: func(r *model.Report) interface{} { return &r.DeletedBy }},
+	{expr: "t.activities", key: "activities", name: "activities", editable: true, jsonb: true, field: func(r *model.Report) interface{} { return &r.Activities }},
+	{expr: "t.names", key: "names", name: "names", editable: true, jsonb: true, field: func(r *model.Report) interface{} { return &r.Names }},
+	{expr: "t.narrative_report", key: "narrative_report", name: "narrative_report", editable: true, field: func(r *model.Report) interface{} { return &r.NarrativeReport }},
+	{expr: "t.challenges_and_problem_encountered", key: "challenges_and_problem_encountered", name: "challenges_and_problem_encountered", editable: true, field: func(r *model.Report) interface{} { return &r.ChallengesAndProblemEncountered }},
+	{expr: "t.prayer_request", key: "prayer_request", name: "prayer_request", editable: true, field: func(r *model.Report) interface{} { return &r.PrayerRequest }},
+	{expr: "t.stats", key: "stats", name: "stats", editable: true, jsonb: true, field: func(r *model.Report) interface{} { return &r.Stats }},
+}
+
+// selectedColumns returns the columns of the given JSON fields, in
+// registry order, or all of them when fields is empty. The id is always
+// selected since paging and links rely on it.
+func selectedColumns(fields []string) []reportColumn {
+	if len(fields) == 0 {
+		return reportColumns
+	}
+
+	wanted := map[string]bool{"id": true}
+	for _, field := range fields {
+		wanted[field] = true
+	}
+
+	var columns []reportColumn
+	for _, column := range reportColumns {
+		if wanted[column.key] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// reportSelectList joins the expressions of columns for a SELECT.
+func reportSelectList(columns []reportColumn) string {
+	exprs := make([]string, len(columns))
+	for i, column := range columns {
+		exprs[i] = column.expr
+	}
+	return strings.Join(exprs, ",\n\t\t\t")
+}
+
+// scanTargets returns the destinations for a row selected with
+// reportSelectList of the same columns.
+func scanTargets(columns []reportColumn, report *model.Report) []interface{} {
+	targets := make([]interface{}, len(columns))
+	for i, column := range columns {
+		targets[i] = column.target(report)
+	}
+	return targets
+}
+
+// writtenColumns returns the names and values of the columns Save writes,
+// or with editableOnly the ones Update writes.
+func writtenColumns(report *model.Report, editableOnly bool) ([]string, []interface{}) {
+	var names []string
+	var values []interface{}
+
+	for _, column := range reportColumns {
+		if column.name == "" || (editableOnly && !column.editable) {
+			continue
+		}
+
+		names = append(names, column.name)
+		if column.jsonb {
+			values = append(values, column.target(report))
+		} else {
+			values = append(values, reflect.ValueOf(column.field(report)).Elem().Interface())
+		}
+	}
+
+	return names, values
+}
+
+func (c reportColumn) target(report *model.Report) interface{} {
+	if c.jsonb {
+		return &jsonColumn{column: c.name, target: c.field(report)}
+	}
+	return c.field(report)
+}
+
+// placeholders numbers n placeholders from $start.
+func placeholders(start, n int) []string {
+	list := make([]string, n)
+	for i := range list {
+		list[i] = "$" + strconv.Itoa(start+i)
+	}
+	return list
+}
+
+// MalformedJSONError is returned when a JSONB column holds a value that does
+// not fit the field it is read into.
+type MalformedJSONError struct {
+	Column string
+	Err    error
+}
+
+func (e *MalformedJSONError) Error() string {
+	return fmt.Sprintf("column %s holds malformed JSON: %v", e.Column, e.Err)
+}
+
+func (e *MalformedJSONError) Unwrap() error {
+	return e.Err
+}
+
+// jsonColumn reads a JSONB column into the field target points to and
+// writes the field back as JSON. SQL NULL leaves the field as it is.
+type jsonColumn struct {
+	column string
+	target interface{}
+}
+
+func (c *jsonColumn) Scan(src interface{}) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return &MalformedJSONError{Column: c.column, Err: fmt.Errorf("cannot scan %T", src)}
+	}
+
+	if err := json.Unmarshal(data, c.target); err != nil {
+		return &MalformedJSONError{Column: c.column, Err: err}
+	}
+	return nil
+}
+
+// Value lets fields with their own encoding, like the empty object stored
+// for nil activities, write themselves.
+func (c *jsonColumn) Value() (driver.Value, error) {
+	if valuer, ok := c.target.(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	return json.Marshal(c.target)
+}
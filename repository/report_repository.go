@@ -2,14 +2,27 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"reports/model"
+	"time"
 )
 
+// ReportRepository writes reports in a transaction from BeginTx, so the
+// service can store the report's revision in the same transaction. Update
+// and UpdateStatus return sql.ErrNoRows when the report was approved,
+// moved on or trashed since it was read.
 type ReportRepository interface {
-	Save(ctx context.Context, report *model.Report) error
-	Update(ctx context.Context, report *model.Report) error
-	Delete(ctx context.Context, reportId int) error
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+	Save(ctx context.Context, tx *sql.Tx, report *model.Report) error
+	Update(ctx context.Context, tx *sql.Tx, report *model.Report) error
+	UpdateStatus(ctx context.Context, tx *sql.Tx, report *model.Report, from string) error
+	Delete(ctx context.Context, tx *sql.Tx, reportId int, deletedBy int, deletedAt time.Time) error
+	Restore(ctx context.Context, tx *sql.Tx, reportId int) error
+	UpdateStats(ctx context.Context, reportId int, stats model.ReportStats) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 	FindById(ctx context.Context, reportId int) (*model.Report, error)
+	FindDeletedById(ctx context.Context, reportId int) (*model.Report, error)
 	FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error)
-	ReportTaken(ctx context.Context, id int, monthOf, workerName string) ([]*model.Report, error)
+	Stream(ctx context.Context, query *model.SearchReportQuery, fn func(report *model.Report) error) error
+	ReportTaken(ctx context.Context, id int, monthOf model.Period, workerId int) (*model.Report, error)
 }
@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
 	"reports/helper"
 	"reports/model"
 	"strconv"
 	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
 )
@@ -20,887 +23,684 @@ func NewReportRepository(Db *sql.DB) ReportRepository {
 	return &ReportRepositoryImpl{Db: Db}
 }
 
-func (r *ReportRepositoryImpl) Delete(ctx context.Context, reportId int) error {
-	tx, err := r.Db.BeginTx(ctx, nil)
+// BeginTx starts the transaction the write methods run in. The caller
+// commits it, usually together with the report's revision.
+func (r *ReportRepositoryImpl) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return r.Db.BeginTx(ctx, nil)
+}
+
+// Delete moves a report to the trash. It stays in the table, hidden from
+// FindById and FindAll, until it is restored or purged.
+func (r *ReportRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, reportId int, deletedBy int, deletedAt time.Time) error {
+	rawSQL := `
+		UPDATE reports SET
+			deleted_at = $1,
+			deleted_by = NULLIF($2, 0)
+		WHERE id = $3
+			AND deleted_at IS NULL
+	`
+
+	_, err := tx.ExecContext(ctx, rawSQL, deletedAt, deletedBy, reportId)
 	if err != nil {
 		return err
 	}
-	defer helper.CommitOrRollback(tx)
 
+	return nil
+}
+
+// Restore takes a report back out of the trash.
+func (r *ReportRepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, reportId int) error {
 	rawSQL := `
-		DELETE FROM reports
+		UPDATE reports SET
+			deleted_at = NULL,
+			deleted_by = NULL
 		WHERE id = $1
+			AND deleted_at IS NOT NULL
 	`
 
-	_, err = tx.ExecContext(ctx, rawSQL, reportId)
+	result, err := tx.ExecContext(ctx, rawSQL, reportId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
 	return nil
 }
 
-func (r *ReportRepositoryImpl) FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error) {
+// Purge permanently removes the reports that were moved to the trash before
+// the given time and returns how many were removed.
+func (r *ReportRepositoryImpl) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
 	tx, err := r.Db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 	defer helper.CommitOrRollback(tx)
 
-	var rawSQL strings.Builder
-	rawSQL.WriteString(`
-		SELECT
-			id,
-			month_of,
-			worker_name,
-			area_of_assignment,
-			name_of_church,
-			created_at,
-			updated_at,
-			worship_service,
-			sunday_school,
-			prayer_meetings,
-			bible_studies,
-			mens_fellowships,
-			womens_fellowships,
-			youth_fellowships,
-			child_fellowships,
-			outreach,
-			training_or_seminars,
-			leadership_conferences,
-			leadership_training,
-			others,
-			family_days,
-			tithes_and_offerings,
-			home_visited,
-			bible_study_or_group_led,
-			sermon_or_message_preached,
-			person_newly_contacted,
-			person_followed_up,
-			person_led_to_christ,
-			names,
-			narrative_report,
-			challenges_and_problem_encountered,
-			prayer_request
-		FROM reports t
-	`)
-
-	var whereConditions []string
-	var whereParams []interface{}
-	index := 1
+	rawSQL := `
+		DELETE FROM reports
+		WHERE deleted_at IS NOT NULL
+			AND deleted_at < $1
+	`
 
-	// Adding dynamic conditions based on query parameters
-	if query.MonthOf != "" {
-		monthOfParam := "%" + strings.ToLower(query.MonthOf) + "%"
-		whereConditions = append(whereConditions, "LOWER(t.month_of) LIKE $"+strconv.Itoa(index))
-		whereParams = append(whereParams, monthOfParam)
-		index++
+	result, err := tx.ExecContext(ctx, rawSQL, deletedBefore)
+	if err != nil {
+		return 0, err
 	}
-	if query.WorkerName != "" {
-		workerNameParam := "%" + strings.ToLower(query.WorkerName) + "%"
-		whereConditions = append(whereConditions, "LOWER(t.worker_name) LIKE $"+strconv.Itoa(index))
-		whereParams = append(whereParams, workerNameParam)
-		index++
+
+	return result.RowsAffected()
+}
+
+// FindAll returns a page of the reports matching query together with the
+// number of matching reports. Pages are numbered from 1; when query.After is
+// set the page instead starts after that report id, in id order, so rows
+// inserted meanwhile do not shift the pages.
+func (r *ReportRepositoryImpl) FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error) {
+	// Count and page from the same snapshot so they agree
+	tx, err := r.Db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
 	}
+	defer helper.CommitOrRollback(tx)
 
+	whereConditions, whereParams := reportConditions(query)
+	where := ""
 	if len(whereConditions) > 0 {
-		rawSQL.WriteString(" WHERE ")
-		rawSQL.WriteString(strings.Join(whereConditions, " AND "))
+		where = " WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
-	rawSQL.WriteString(" ORDER BY t.id") // Replace with your desired ordering column
-
-	// Pagination
-	rawSQL.WriteString(" LIMIT $")
-	rawSQL.WriteString(strconv.Itoa(index))
-	rawSQL.WriteString(" OFFSET $")
-	rawSQL.WriteString(strconv.Itoa(index + 1))
+	var totalCount int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*)"+reportsFromSQL+where, whereParams...).Scan(&totalCount); err != nil {
+		return nil, err
+	}
 
-	// Append pagination parameters to args slice
-	whereParams = append(whereParams, query.PerPage, (query.Page-1)*query.PerPage)
+	columns := selectedColumns(query.Fields)
+	rawSQL, params, err := reportPageSQL(query, columns)
+	if err != nil {
+		return nil, err
+	}
 
-	// Execute query
-	rows, err := tx.QueryContext(ctx, rawSQL.String(), whereParams...)
+	rows, err := tx.QueryContext(ctx, rawSQL, params...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var reports []*model.Report // Changed to []*model.Report to match SearchReportResult.Reports
-
+	reports := []*model.Report{}
 	for rows.Next() {
-		var report model.Report
-		var (
-			worshipServiceJSON        []byte
-			sundaySchoolJSON          []byte
-			prayerMeetingsJSON        []byte
-			bibleStudiesJSON          []byte
-			mensFellowshipsJSON       []byte
-			womensFellowshipsJSON     []byte
-			youthFellowshipsJSON      []byte
-			childFellowshipsJSON      []byte
-			outreachJSON              []byte
-			trainingOrSeminarsJSON    []byte
-			leadershipConferencesJSON []byte
-			leadershipTrainingJSON    []byte
-			othersJSON                []byte
-			familyDaysJSON            []byte
-			tithesAndOfferingsJSON    []byte
-			homeVisitedJSON           []byte
-			bibleStudyOrGroupLedJSON  []byte
-			sermonOrMessageJSON       []byte
-			personNewlyContactedJSON  []byte
-			personFollowedUpJSON      []byte
-			personLedToChristJSON     []byte
-			namesJSON                 []byte
-		)
-
-		// Scan row into variables
-		err := rows.Scan(
-			&report.Id,
-			&report.MonthOf,
-			&report.WorkerName,
-			&report.AreaOfAssignment,
-			&report.NameOfChurch,
-			&report.CreatedAt,
-			&report.UpdatedAt,
-			&worshipServiceJSON,
-			&sundaySchoolJSON,
-			&prayerMeetingsJSON,
-			&bibleStudiesJSON,
-			&mensFellowshipsJSON,
-			&womensFellowshipsJSON,
-			&youthFellowshipsJSON,
-			&childFellowshipsJSON,
-			&outreachJSON,
-			&trainingOrSeminarsJSON,
-			&leadershipConferencesJSON,
-			&leadershipTrainingJSON,
-			&othersJSON,
-			&familyDaysJSON,
-			&tithesAndOfferingsJSON,
-			&homeVisitedJSON,
-			&bibleStudyOrGroupLedJSON,
-			&sermonOrMessageJSON,
-			&personNewlyContactedJSON,
-			&personFollowedUpJSON,
-			&personLedToChristJSON,
-			&namesJSON,
-			&report.NarrativeReport,
-			&report.ChallengesAndProblemEncountered,
-			&report.PrayerRequest,
-		)
+		var report *model.Report
+		if query.Search != "" {
+			report, err = scanSearchResult(rows, columns)
+		} else {
+			report, err = scanReport(rows, columns)
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		// Unmarshal JSONB fields into respective slices or fields
-		if worshipServiceJSON != nil {
-			if err := json.Unmarshal(worshipServiceJSON, &report.WorshipService); err != nil {
-				return nil, err
-			}
-		}
+		reports = append(reports, report)
+	}
 
-		if sundaySchoolJSON != nil {
-			if err := json.Unmarshal(sundaySchoolJSON, &report.SundaySchool); err != nil {
-				return nil, err
-			}
-		}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-		if prayerMeetingsJSON != nil {
-			if err := json.Unmarshal(prayerMeetingsJSON, &report.PrayerMeetings); err != nil {
-				return nil, err
-			}
-		}
+	return reportPage(query, totalCount, reports), nil
+}
 
-		if bibleStudiesJSON != nil {
-			if err := json.Unmarshal(bibleStudiesJSON, &report.BibleStudies); err != nil {
-				return nil, err
-			}
-		}
+// reportPageSQL builds the query reading the page of query: up to PerPage+1
+// reports, the one extra telling whether another page follows. With After
+// the page is keyset paged on the id instead of offset by the page number.
+func reportPageSQL(query *model.SearchReportQuery, columns []reportColumn) (string, []interface{}, error) {
+	whereConditions, whereParams := reportConditions(query)
 
-		if mensFellowshipsJSON != nil {
-			if err := json.Unmarshal(mensFellowshipsJSON, &report.MensFellowships); err != nil {
-				return nil, err
-			}
-		}
+	selectList := reportSelectList(columns)
+	if query.Search != "" {
+		whereParams = append(whereParams, query.Search)
+		selectList += ",\n\t\t\t" + searchSelectList(len(whereParams))
+	}
 
-		if womensFellowshipsJSON != nil {
-			if err := json.Unmarshal(womensFellowshipsJSON, &report.WomensFellowships); err != nil {
-				return nil, err
-			}
-		}
+	var rawSQL strings.Builder
+	rawSQL.WriteString(`
+		SELECT
+			` + selectList + reportsFromSQL)
 
-		if youthFellowshipsJSON != nil {
-			if err := json.Unmarshal(youthFellowshipsJSON, &report.YouthFellowships); err != nil {
-				return nil, err
-			}
-		}
+	if query.After > 0 {
+		whereParams = append(whereParams, query.After)
+		whereConditions = append(whereConditions, "t.id > $"+strconv.Itoa(len(whereParams)))
+	}
 
-		if childFellowshipsJSON != nil {
-			if err := json.Unmarshal(childFellowshipsJSON, &report.ChildFellowships); err != nil {
-				return nil, err
-			}
-		}
+	if len(whereConditions) > 0 {
+		rawSQL.WriteString(" WHERE ")
+		rawSQL.WriteString(strings.Join(whereConditions, " AND "))
+	}
 
-		if outreachJSON != nil {
-			if err := json.Unmarshal(outreachJSON, &report.Outreach); err != nil {
-				return nil, err
-			}
-		}
+	orderBy, whereParams, err := reportOrderBy(query, whereParams)
+	if err != nil {
+		return "", nil, err
+	}
+	rawSQL.WriteString(" ORDER BY ")
+	rawSQL.WriteString(orderBy)
 
-		if trainingOrSeminarsJSON != nil {
-			if err := json.Unmarshal(trainingOrSeminarsJSON, &report.TrainingOrSeminars); err != nil {
-				return nil, err
-			}
-		}
+	whereParams = append(whereParams, query.PerPage+1)
+	rawSQL.WriteString(" LIMIT $" + strconv.Itoa(len(whereParams)))
 
-		if leadershipConferencesJSON != nil {
-			if err := json.Unmarshal(leadershipConferencesJSON, &report.LeadershipConferences); err != nil {
-				return nil, err
-			}
-		}
+	if query.After == 0 {
+		whereParams = append(whereParams, (query.Page-1)*query.PerPage)
+		rawSQL.WriteString(" OFFSET $" + strconv.Itoa(len(whereParams)))
+	}
 
-		if leadershipTrainingJSON != nil {
-			if err := json.Unmarshal(leadershipTrainingJSON, &report.LeadershipTraining); err != nil {
-				return nil, err
-			}
-		}
+	return rawSQL.String(), whereParams, nil
+}
 
-		if othersJSON != nil {
-			if err := json.Unmarshal(othersJSON, &report.Others); err != nil {
-				return nil, err
-			}
-		}
+// reportPage wraps the reports read by reportPageSQL, at most one more than
+// a page, with the paging details of query.
+func reportPage(query *model.SearchReportQuery, totalCount int, reports []*model.Report) *model.SearchReportResult {
+	result := &model.SearchReportResult{
+		TotalCount: totalCount,
+		TotalPages: (totalCount + query.PerPage - 1) / query.PerPage,
+		Page:       query.Page,
+		PerPage:    query.PerPage,
+		After:      query.After,
+		Fields:     query.Fields,
+	}
 
-		if familyDaysJSON != nil {
-			if err := json.Unmarshal(familyDaysJSON, &report.FamilyDays); err != nil {
-				return nil, err
-			}
+	if len(reports) > query.PerPage {
+		reports = reports[:query.PerPage]
+		result.HasNext = true
+		if query.After > 0 {
+			result.NextAfter = reports[len(reports)-1].Id
 		}
+	}
+	result.Reports = reports
 
-		if tithesAndOfferingsJSON != nil {
-			if err := json.Unmarshal(tithesAndOfferingsJSON, &report.TithesAndOfferings); err != nil {
-				return nil, err
-			}
-		}
+	return result
+}
 
-		if homeVisitedJSON != nil {
-			if err := json.Unmarshal(homeVisitedJSON, &report.HomeVisited); err != nil {
-				return nil, err
-			}
-		}
+// Stream calls fn for every report matching query, reading them one at a
+// time from the database cursor instead of loading a page into memory.
+// Pagination is ignored; reports come ordered by month, area, church and
+// worker.
+func (r *ReportRepositoryImpl) Stream(ctx context.Context, query *model.SearchReportQuery, fn func(report *model.Report) error) error {
+	var rawSQL strings.Builder
+	rawSQL.WriteString(selectReportsSQL)
 
-		if bibleStudyOrGroupLedJSON != nil {
-			if err := json.Unmarshal(bibleStudyOrGroupLedJSON, &report.BibleStudyOrGroupLed); err != nil {
-				return nil, err
-			}
-		}
+	whereConditions, whereParams := reportConditions(query)
+	if len(whereConditions) > 0 {
+		rawSQL.WriteString(" WHERE ")
+		rawSQL.WriteString(strings.Join(whereConditions, " AND "))
+	}
 
-		if sermonOrMessageJSON != nil {
-			if err := json.Unmarshal(sermonOrMessageJSON, &report.SermonOrMessagePreached); err != nil {
-				return nil, err
-			}
-		}
+	rawSQL.WriteString(" ORDER BY t.month_of, a.name, c.name, w.name, t.id")
 
-		if personNewlyContactedJSON != nil {
-			if err := json.Unmarshal(personNewlyContactedJSON, &report.PersonNewlyContacted); err != nil {
-				return nil, err
-			}
-		}
+	rows, err := r.Db.QueryContext(ctx, rawSQL.String(), whereParams...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
-		if personFollowedUpJSON != nil {
-			if err := json.Unmarshal(personFollowedUpJSON, &report.PersonFollowedUp); err != nil {
-				return nil, err
-			}
+	for rows.Next() {
+		report, err := scanReport(rows, reportColumns)
+		if err != nil {
+			return err
 		}
 
-		if personLedToChristJSON != nil {
-			if err := json.Unmarshal(personLedToChristJSON, &report.PersonLedToChrist); err != nil {
-				return nil, err
-			}
+		if err := fn(report); err != nil {
+			return err
 		}
+	}
 
-		if namesJSON != nil {
-			if err := json.Unmarshal(namesJSON, &report.Names); err != nil {
-				return nil, err
-			}
+	return rows.Err()
+}
+
+// reportsFromSQL joins reports with the worker, area and church names the
+// filters of reportConditions refer to.
+const reportsFromSQL = `
+		FROM reports t
+		JOIN workers w ON w.id = t.worker_id
+		JOIN areas a ON a.id = t.area_id
+		JOIN churches c ON c.id = t.church_id
+`
+
+// selectReportsSQL selects every column of reportColumns.
+var selectReportsSQL = `
+		SELECT
+			` + reportSelectList(reportColumns) + reportsFromSQL
+
+// reportConditions builds the WHERE conditions shared by FindAll and Stream,
+// numbering the placeholders from $1.
+func reportConditions(query *model.SearchReportQuery) ([]string, []interface{}) {
+	var whereConditions []string
+	var whereParams []interface{}
+	index := 1
+
+	if query.Deleted {
+		whereConditions = append(whereConditions, "t.deleted_at IS NOT NULL")
+	} else {
+		whereConditions = append(whereConditions, "t.deleted_at IS NULL")
+	}
+
+	// Adding dynamic conditions based on query parameters
+	if query.MonthOf != "" {
+		if period, err := model.ParsePeriod(query.MonthOf); err == nil {
+			whereConditions = append(whereConditions, "t.month_of = $"+strconv.Itoa(index))
+			whereParams = append(whereParams, period)
+		} else {
+			monthOfParam := "%" + strings.ToLower(query.MonthOf) + "%"
+			whereConditions = append(whereConditions, "LOWER(TO_CHAR(t.month_of, 'FMMonth YYYY')) LIKE $"+strconv.Itoa(index))
+			whereParams = append(whereParams, monthOfParam)
 		}
+		index++
+	}
+	if query.WorkerName != "" {
+		workerNameParam := "%" + strings.ToLower(query.WorkerName) + "%"
+		whereConditions = append(whereConditions, "LOWER(w.name) LIKE $"+strconv.Itoa(index))
+		whereParams = append(whereParams, workerNameParam)
+		index++
+	}
+	if query.WorkerId > 0 {
+		whereConditions = append(whereConditions, "t.worker_id = $"+strconv.Itoa(index))
+		whereParams = append(whereParams, query.WorkerId)
+		index++
+	}
+	if query.AreaId > 0 {
+		whereConditions = append(whereConditions, "t.area_id = $"+strconv.Itoa(index))
+		whereParams = append(whereParams, query.AreaId)
+		index++
+	}
+	if query.ChurchId > 0 {
+		whereConditions = append(whereConditions, "t.church_id = $"+strconv.Itoa(index))
+		whereParams = append(whereParams, query.ChurchId)
+		index++
+	}
+	if query.Status != "" {
+		whereConditions = append(whereConditions, "t.status = $"+strconv.Itoa(index))
+		whereParams = append(whereParams, query.Status)
+		index++
+	} else if query.SubmittedOnly {
+		whereConditions = append(whereConditions, fmt.Sprintf("t.status IN ('%s', '%s')", model.ReportStatusSubmitted, model.ReportStatusApproved))
+	}
+	if query.ScopeWorkerId > 0 {
+		whereConditions = append(whereConditions, "t.worker_id = $"+strconv.Itoa(index))
+		whereParams = append(whereParams, query.ScopeWorkerId)
+		index++
+	}
+	if query.ScopeAreaId > 0 {
+		whereConditions = append(whereConditions, "t.area_id = $"+strconv.Itoa(index))
+		whereParams = append(whereParams, query.ScopeAreaId)
+		index++
+	}
+	if !query.MonthFrom.IsZero() {
+		whereConditions = append(whereConditions, "t.month_of >= $"+strconv.Itoa(index))
+		whereParams = append(whereParams, query.MonthFrom)
+		index++
+	}
+	if !query.MonthTo.IsZero() {
+		whereConditions = append(whereConditions, "t.month_of <= $"+strconv.Itoa(index))
+		whereParams = append(whereParams, query.MonthTo)
+		index++
+	}
 
-		reports = append(reports, &report) // Append pointer to report
+	for _, bound := range []struct {
+		column string
+		from   time.Time
+		to     time.Time
+	}{
+		{"t.created_at", query.CreatedFrom, query.CreatedTo},
+		{"t.updated_at", query.UpdatedFrom, query.UpdatedTo},
+	} {
+		if !bound.from.IsZero() {
+			whereConditions = append(whereConditions, bound.column+" >= $"+strconv.Itoa(index))
+			whereParams = append(whereParams, bound.from)
+			index++
+		}
+		if !bound.to.IsZero() {
+			whereConditions = append(whereConditions, bound.column+" < $"+strconv.Itoa(index))
+			whereParams = append(whereParams, bound.to.AddDate(0, 0, 1))
+			index++
+		}
 	}
 
-	// Check for any error during iteration
-	if err := rows.Err(); err != nil {
-		return nil, err
+	if query.Search != "" {
+		whereConditions = append(whereConditions, "t.search_vector @@ "+searchQuerySQL(index))
+		whereParams = append(whereParams, query.Search)
+		index++
 	}
 
-	// Construct the result object
-	result := &model.SearchReportResult{
-		TotalCount: len(reports), // Assuming you want total count of items fetched
-		Reports:    reports,
-		Page:       query.Page,
-		PerPage:    query.PerPage,
+	for _, metric := range query.Metrics {
+		operator, ok := metricOperators[metric.Operator]
+		if !ok {
+			continue
+		}
+
+		whereConditions = append(whereConditions, metricSQL(index)+" "+operator+" $"+strconv.Itoa(index+2))
+		whereParams = append(whereParams, metric.Activity, metric.Measure, metric.Value)
+		index += 3
 	}
 
-	return result, nil
+	return whereConditions, whereParams
 }
 
-// FindById implements BookRepository
-func (r *ReportRepositoryImpl) FindById(ctx context.Context, id int) (*model.Report, error) {
-	tx, err := r.Db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer helper.CommitOrRollback(tx)
+// metricOperators whitelists the comparisons of a MetricFilter.
+var metricOperators = map[string]string{
+	"=":  "=",
+	"!=": "<>",
+	">":  ">",
+	">=": ">=",
+	"<":  "<",
+	"<=": "<=",
+}
 
-	rawSQL := `
-		SELECT 
-			id,
-			month_of,
-			worker_name,
-			area_of_assignment,
-			name_of_church,
-			created_at,
-			updated_at,
-			worship_service,
-			sunday_school,
-			prayer_meetings,
-			bible_studies,
-			mens_fellowships,
-			womens_fellowships,
-			youth_fellowships,
-			child_fellowships,
-			outreach,
-			training_or_seminars,
-			leadership_conferences,
-			leadership_training,
-			others,
-			family_days,
-			tithes_and_offerings,
-			home_visited,
-			bible_study_or_group_led,
-			sermon_or_message_preached,
-			person_newly_contacted,
-			person_followed_up,
-			person_led_to_christ,
-			names,
-			narrative_report,
-			challenges_and_problem_encountered,
-			prayer_request
-		FROM reports
-		WHERE id = $1
-	`
+// reportSortColumns maps the fields of model.ReportSortFields to the
+// expressions they sort on. Only these ever reach ORDER BY.
+var reportSortColumns = map[string]string{
+	"id":                 "t.id",
+	"month_of":           "t.month_of",
+	"worker_name":        "w.name",
+	"area_of_assignment": "a.name",
+	"name_of_church":     "c.name",
+	"status":             "t.status",
+	"created_at":         "t.created_at",
+	"updated_at":         "t.updated_at",
+	"submitted_at":       "t.submitted_at",
+}
 
-	var report model.Report
-	var (
-		worshipServiceJSON        []byte
-		sundaySchoolJSON          []byte
-		prayerMeetingsJSON        []byte
-		bibleStudiesJSON          []byte
-		mensFellowshipsJSON       []byte
-		womensFellowshipsJSON     []byte
-		youthFellowshipsJSON      []byte
-		childFellowshipsJSON      []byte
-		outreachJSON              []byte
-		trainingOrSeminarsJSON    []byte
-		leadershipConferencesJSON []byte
-		leadershipTrainingJSON    []byte
-		othersJSON                []byte
-		familyDaysJSON            []byte
-		tithesAndOfferingsJSON    []byte
-		homeVisitedJSON           []byte
-		bibleStudyOrGroupLedJSON  []byte
-		sermonOrMessageJSON       []byte
-		personNewlyContactedJSON  []byte
-		personFollowedUpJSON      []byte
-		personLedToChristJSON     []byte
-		namesJSON                 []byte
-	)
+// metricSQL reads a measure of the stats of an activity, with the activity
+// key in $index and the measure in $index+1. Reports without the activity
+// count as zero.
+func metricSQL(index int) string {
+	return "COALESCE((t.stats -> $" + strconv.Itoa(index) + "::text ->> $" + strconv.Itoa(index+1) + "::text)::numeric, 0)"
+}
 
-	err = tx.QueryRowContext(ctx, rawSQL, id).Scan(
-		&report.Id,
-		&report.MonthOf,
-		&report.WorkerName,
-		&report.AreaOfAssignment,
-		&report.NameOfChurch,
-		&report.CreatedAt,
-		&report.UpdatedAt,
-		&worshipServiceJSON,
-		&sundaySchoolJSON,
-		&prayerMeetingsJSON,
-		&bibleStudiesJSON,
-		&mensFellowshipsJSON,
-		&womensFellowshipsJSON,
-		&youthFellowshipsJSON,
-		&childFellowshipsJSON,
-		&outreachJSON,
-		&trainingOrSeminarsJSON,
-		&leadershipConferencesJSON,
-		&leadershipTrainingJSON,
-		&othersJSON,
-		&familyDaysJSON,
-		&tithesAndOfferingsJSON,
-		&homeVisitedJSON,
-		&bibleStudyOrGroupLedJSON,
-		&sermonOrMessageJSON,
-		&personNewlyContactedJSON,
-		&personFollowedUpJSON,
-		&personLedToChristJSON,
-		&namesJSON,
-		&report.NarrativeReport,
-		&report.ChallengesAndProblemEncountered,
-		&report.PrayerRequest,
-	)
-	if err != nil {
-		return nil, err
-	}
+// searchQuerySQL turns the web search syntax query in $index into a
+// tsquery matching both the stemmed and the word for word lexemes of
+// search_vector.
+func searchQuerySQL(index int) string {
+	placeholder := "$" + strconv.Itoa(index)
+	return "(websearch_to_tsquery('english', " + placeholder + ") || websearch_to_tsquery('simple', " + placeholder + "))"
+}
 
-	// Unmarshal JSONB fields into their respective slices
-	unmarshalJSONFields(&report, worshipServiceJSON, sundaySchoolJSON, prayerMeetingsJSON, bibleStudiesJSON, mensFellowshipsJSON, womensFellowshipsJSON, youthFellowshipsJSON, childFellowshipsJSON, outreachJSON, trainingOrSeminarsJSON, leadershipConferencesJSON, leadershipTrainingJSON, othersJSON, familyDaysJSON, tithesAndOfferingsJSON, homeVisitedJSON, bibleStudyOrGroupLedJSON, sermonOrMessageJSON, personNewlyContactedJSON, personFollowedUpJSON, personLedToChristJSON, namesJSON)
+// searchedSections are the text sections highlighted on search results, in
+// the order searchSelectList selects them.
+var searchedSections = []struct {
+	name   string
+	column string
+}{
+	{"narrative_report", "t.narrative_report"},
+	{"challenges_and_problem_encountered", "t.challenges_and_problem_encountered"},
+	{"prayer_request", "t.prayer_request"},
+}
 
-	return &report, nil
+// Matches are marked with control characters no text keeps, see
+// searchSelectList, and only turned into <mark> tags once the snippet
+// around them is escaped.
+const (
+	matchStart = "\x01"
+	matchStop  = "\x02"
+
+	headlineOptions = `StartSel="` + matchStart + `", StopSel="` + matchStop + `", MaxFragments=2, MaxWords=25, MinWords=8, FragmentDelimiter=" ... "`
+)
+
+// searchSelectList selects the rank and a highlighted snippet of every
+// searched section for the query in $index. Postgres only works out these
+// expensive columns for the rows that make it onto the page.
+func searchSelectList(index int) string {
+	tsquery := searchQuerySQL(index)
+	exprs := []string{"ts_rank(t.search_vector, " + tsquery + ")"}
+	for _, section := range searchedSections {
+		text := "translate(" + section.column + ", chr(1) || chr(2), '')"
+		exprs = append(exprs, "ts_headline('english', "+text+", "+tsquery+", '"+headlineOptions+"')")
+	}
+	return strings.Join(exprs, ",\n\t\t\t")
 }
 
-// Helper function to unmarshal JSON fields
-func unmarshalJSONFields(report *model.Report, worshipServiceJSON, sundaySchoolJSON, prayerMeetingsJSON, bibleStudiesJSON, mensFellowshipsJSON, womensFellowshipsJSON, youthFellowshipsJSON, childFellowshipsJSON, outreachJSON, trainingOrSeminarsJSON, leadershipConferencesJSON, leadershipTrainingJSON, othersJSON, familyDaysJSON, tithesAndOfferingsJSON, homeVisitedJSON, bibleStudyOrGroupLedJSON, sermonOrMessageJSON, personNewlyContactedJSON, personFollowedUpJSON, personLedToChristJSON, namesJSON []byte) {
-	jsonFields := []struct {
-		jsonData []byte
-		target   interface{}
-	}{
-		{worshipServiceJSON, &report.WorshipService},
-		{sundaySchoolJSON, &report.SundaySchool},
-		{prayerMeetingsJSON, &report.PrayerMeetings},
-		{bibleStudiesJSON, &report.BibleStudies},
-		{mensFellowshipsJSON, &report.MensFellowships},
-		{womensFellowshipsJSON, &report.WomensFellowships},
-		{youthFellowshipsJSON, &report.YouthFellowships},
-		{childFellowshipsJSON, &report.ChildFellowships},
-		{outreachJSON, &report.Outreach},
-		{trainingOrSeminarsJSON, &report.TrainingOrSeminars},
-		{leadershipConferencesJSON, &report.LeadershipConferences},
-		{leadershipTrainingJSON, &report.LeadershipTraining},
-		{othersJSON, &report.Others},
-		{familyDaysJSON, &report.FamilyDays},
-		{tithesAndOfferingsJSON, &report.TithesAndOfferings},
-		{homeVisitedJSON, &report.HomeVisited},
-		{bibleStudyOrGroupLedJSON, &report.BibleStudyOrGroupLed},
-		{sermonOrMessageJSON, &report.SermonOrMessagePreached},
-		{personNewlyContactedJSON, &report.PersonNewlyContacted},
-		{personFollowedUpJSON, &report.PersonFollowedUp},
-		{personLedToChristJSON, &report.PersonLedToChrist},
-		{namesJSON, &report.Names},
-	}
-
-	for _, field := range jsonFields {
-		if field.jsonData != nil {
-			if err := json.Unmarshal(field.jsonData, field.target); err != nil {
-				panic(err)
-			}
-		}
-	}
+// highlightSnippet escapes the text of a snippet from ts_headline and marks
+// its matches with <mark></mark>, so the text a worker typed can never pass
+// for markup.
+func highlightSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	return strings.NewReplacer(matchStart, "<mark>", matchStop, "</mark>").Replace(escaped)
 }
 
-// Save implements BookRepository
-func (r *ReportRepositoryImpl) Save(ctx context.Context, report *model.Report) error {
-	tx, err := r.Db.Begin()
-	if err != nil {
-		return err
-	}
-	defer helper.CommitOrRollback(tx)
+// scanSearchResult reads a row selected with reportSelectList of columns
+// followed by searchSelectList. Snippets of sections without a match are
+// left out.
+func scanSearchResult(row rowScanner, columns []reportColumn) (*model.Report, error) {
+	var report model.Report
+	match := &model.ReportSearchMatch{Highlights: map[string]string{}}
+	snippets := make([]string, len(searchedSections))
 
-	// Marshal arrays to JSONB
-	worshipServiceJSON, err := json.Marshal(report.WorshipService)
-	if err != nil {
-		return err
+	targets := append(scanTargets(columns, &report), &match.Rank)
+	for i := range snippets {
+		targets = append(targets, &snippets[i])
 	}
 
-	sundaySchoolJSON, err := json.Marshal(report.SundaySchool)
-	if err != nil {
-		return err
+	if err := row.Scan(targets...); err != nil {
+		return nil, err
 	}
 
-	prayerMeetingsJSON, err := json.Marshal(report.PrayerMeetings)
-	if err != nil {
-		return err
+	for i, section := range searchedSections {
+		if strings.Contains(snippets[i], matchStart) {
+			match.Highlights[section.name] = highlightSnippet(snippets[i])
+		}
 	}
+	report.Search = match
 
-	bibleStudiesJSON, err := json.Marshal(report.BibleStudies)
-	if err != nil {
-		return err
-	}
+	return &report, nil
+}
 
-	mensFellowshipsJSON, err := json.Marshal(report.MensFellowships)
-	if err != nil {
-		return err
+// reportOrderBy builds the ORDER BY list of FindAll, appending the
+// parameters of activity measures to params. The id always comes last so
+// pages are stable. Keyset paging only works in id order.
+func reportOrderBy(query *model.SearchReportQuery, params []interface{}) (string, []interface{}, error) {
+	if query.After > 0 {
+		if len(query.Sort) > 0 {
+			return "", nil, errors.New("sort cannot be combined with after")
+		}
+		return "t.id", params, nil
 	}
 
-	womensFellowshipsJSON, err := json.Marshal(report.WomensFellowships)
-	if err != nil {
-		return err
-	}
+	if len(query.Sort) == 0 {
+		if query.Search != "" {
+			params = append(params, query.Search)
+			return "ts_rank(t.search_vector, " + searchQuerySQL(len(params)) + ") DESC, t.id", params, nil
+		}
+		if query.Deleted {
+			return "t.deleted_at DESC, t.id", params, nil
+		}
+		return "t.id", params, nil
+	}
+
+	var keys []string
+	for _, sort := range query.Sort {
+		var expr string
+		if sort.Activity != "" {
+			expr = metricSQL(len(params) + 1)
+			params = append(params, sort.Activity, sort.Measure)
+		} else {
+			column, ok := reportSortColumns[sort.Field]
+			if !ok {
+				return "", nil, fmt.Errorf("cannot sort on %q", sort.Field)
+			}
+			expr = column
+		}
 
-	youthFellowshipsJSON, err := json.Marshal(report.YouthFellowships)
-	if err != nil {
-		return err
+		if sort.Desc {
+			expr += " DESC NULLS LAST"
+		}
+		keys = append(keys, expr)
 	}
 
-	childFellowshipsJSON, err := json.Marshal(report.ChildFellowships)
-	if err != nil {
-		return err
-	}
+	return strings.Join(append(keys, "t.id"), ", "), params, nil
+}
 
-	outreachJSON, err := json.Marshal(report.Outreach)
-	if err != nil {
-		return err
-	}
+// rowScanner is a *sql.Row or *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
 
-	trainingOrSeminarsJSON, err := json.Marshal(report.TrainingOrSeminars)
-	if err != nil {
-		return err
+// scanReport reads a row selected with reportSelectList of columns. A JSONB
+// column that cannot be read fails with a *MalformedJSONError.
+func scanReport(row rowScanner, columns []reportColumn) (*model.Report, error) {
+	var report model.Report
+	if err := row.Scan(scanTargets(columns, &report)...); err != nil {
+		return nil, err
 	}
 
-	leadershipConferencesJSON, err := json.Marshal(report.LeadershipConferences)
-	if err != nil {
-		return err
-	}
+	return &report, nil
+}
 
-	leadershipTrainingJSON, err := json.Marshal(report.LeadershipTraining)
-	if err != nil {
-		return err
-	}
+// FindById implements BookRepository
+func (r *ReportRepositoryImpl) FindById(ctx context.Context, id int) (*model.Report, error) {
+	return r.findById(ctx, id, "t.deleted_at IS NULL")
+}
 
-	othersJSON, err := json.Marshal(report.Others)
-	if err != nil {
-		return err
-	}
+// FindDeletedById finds a report that is in the trash.
+func (r *ReportRepositoryImpl) FindDeletedById(ctx context.Context, id int) (*model.Report, error) {
+	return r.findById(ctx, id, "t.deleted_at IS NOT NULL")
+}
 
-	familyDaysJSON, err := json.Marshal(report.FamilyDays)
+func (r *ReportRepositoryImpl) findById(ctx context.Context, id int, condition string) (*model.Report, error) {
+	tx, err := r.Db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer helper.CommitOrRollback(tx)
 
-	tithesAndOfferingsJSON, err := json.Marshal(report.TithesAndOfferings)
-	if err != nil {
-		return err
-	}
+	rawSQL := selectReportsSQL + `
+		WHERE t.id = $1
+			AND ` + condition
 
-	homeVisitedJSON, err := json.Marshal(report.HomeVisited)
-	if err != nil {
-		return err
-	}
+	return scanReport(tx.QueryRowContext(ctx, rawSQL, id), reportColumns)
+}
 
-	bibleStudyOrGroupLedJSON, err := json.Marshal(report.BibleStudyOrGroupLed)
-	if err != nil {
-		return err
-	}
+// Save implements BookRepository
+func (r *ReportRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, report *model.Report) error {
+	return insertReport(ctx, tx, report)
+}
 
-	sermonOrMessagePreachedJSON, err := json.Marshal(report.SermonOrMessagePreached)
-	if err != nil {
-		return err
-	}
+func insertReport(ctx context.Context, tx *sql.Tx, report *model.Report) error {
+	columns, values := writtenColumns(report, false)
 
-	personNewlyContactedJSON, err := json.Marshal(report.PersonNewlyContacted)
-	if err != nil {
-		return err
-	}
+	rawSQL := `
+		INSERT INTO reports (` + strings.Join(columns, ", ") + `)
+		VALUES (` + strings.Join(placeholders(1, len(values)), ", ") + `)
+		RETURNING id
+	`
 
-	personFollowedUpJSON, err := json.Marshal(report.PersonFollowedUp)
-	if err != nil {
-		return err
-	}
+	return tx.QueryRowContext(ctx, rawSQL, values...).Scan(&report.Id)
+}
 
-	personLedToChristJSON, err := json.Marshal(report.PersonLedToChrist)
-	if err != nil {
-		return err
+// Update implements BookRepository
+func (r *ReportRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, report *model.Report) error {
+	columns, values := writtenColumns(report, true)
+
+	assignments := make([]string, len(columns))
+	for i, column := range columns {
+		assignments[i] = column + " = $" + strconv.Itoa(i+1)
 	}
 
-	namesJSON, err := json.Marshal(report.Names)
+	// An approved or trashed report is left alone even when it got there
+	// after the caller read it
+	rawSQL := `
+		UPDATE reports SET ` + strings.Join(assignments, ", ") + `
+		WHERE id = $` + strconv.Itoa(len(values)+1) + `
+			AND status <> $` + strconv.Itoa(len(values)+2) + `
+			AND deleted_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, rawSQL, append(values, report.Id, model.ReportStatusApproved)...)
 	if err != nil {
 		return err
 	}
+	return requireRowAffected(result)
+}
 
+// UpdateStatus saves the review workflow fields of report, provided it is
+// still in status from and not trashed.
+func (r *ReportRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, report *model.Report, from string) error {
 	rawSQL := `
-		INSERT INTO reports (
-			month_of,
-			worker_name,
-			area_of_assignment,
-			name_of_church,
-			worship_service,
-			sunday_school,
-			prayer_meetings,
-			bible_studies,
-			mens_fellowships,
-			womens_fellowships,
-			youth_fellowships,
-			child_fellowships,
-			outreach,
-			training_or_seminars,
-			leadership_conferences,
-			leadership_training,
-			others,
-			family_days,
-			tithes_and_offerings,
-			home_visited,
-			bible_study_or_group_led,
-			sermon_or_message_preached,
-			person_newly_contacted,
-			person_followed_up,
-			person_led_to_christ,
-			names,
-			narrative_report,
-			challenges_and_problem_encountered,
-			prayer_request,
-			created_at,
-			updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)
+		UPDATE reports SET
+			status = $1,
+			review_comment = $2,
+			submitted_at = $3,
+			reviewed_at = $4,
+			reviewed_by = NULLIF($5, 0),
+			updated_at = $6
+		WHERE id = $7
+			AND status = $8
+			AND deleted_at IS NULL
 	`
 
-	_, err = tx.ExecContext(ctx, rawSQL,
-		report.MonthOf,
-		report.WorkerName,
-		report.AreaOfAssignment,
-		report.NameOfChurch,
-		worshipServiceJSON,
-		sundaySchoolJSON,
-		prayerMeetingsJSON,
-		bibleStudiesJSON,
-		mensFellowshipsJSON,
-		womensFellowshipsJSON,
-		youthFellowshipsJSON,
-		childFellowshipsJSON,
-		outreachJSON,
-		trainingOrSeminarsJSON,
-		leadershipConferencesJSON,
-		leadershipTrainingJSON,
-		othersJSON,
-		familyDaysJSON,
-		tithesAndOfferingsJSON,
-		homeVisitedJSON,
-		bibleStudyOrGroupLedJSON,
-		sermonOrMessagePreachedJSON,
-		personNewlyContactedJSON,
-		personFollowedUpJSON,
-		personLedToChristJSON,
-		namesJSON,
-		report.NarrativeReport,
-		report.ChallengesAndProblemEncountered,
-		report.PrayerRequest,
-		report.CreatedAt,
+	result, err := tx.ExecContext(ctx, rawSQL,
+		report.Status,
+		report.ReviewComment,
+		report.SubmittedAt,
+		report.ReviewedAt,
+		report.ReviewedBy,
 		report.UpdatedAt,
+		report.Id,
+		from,
 	)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return requireRowAffected(result)
 }
 
-// Update implements BookRepository
-func (r *ReportRepositoryImpl) Update(ctx context.Context, report *model.Report) error {
-	tx, err := r.Db.Begin()
-	if err != nil {
-		return err
-	}
-	defer helper.CommitOrRollback(tx)
+// UpdateStats replaces the stored stats of a report, live or trashed.
+func (r *ReportRepositoryImpl) UpdateStats(ctx context.Context, reportId int, stats model.ReportStats) error {
+	_, err := r.Db.ExecContext(ctx, "UPDATE reports SET stats = $1 WHERE id = $2", stats, reportId)
+	return err
+}
 
-	rawSQL := `
-        UPDATE reports SET
-            month_of = $1,
-            worker_name = $2,
-            area_of_assignment = $3,
-            name_of_church = $4,
-            worship_service = $5,
-            sunday_school = $6,
-            prayer_meetings = $7,
-            bible_studies = $8,
-            mens_fellowships = $9,
-            womens_fellowships = $10,
-            youth_fellowships = $11,
-            child_fellowships = $12,
-            outreach = $13,
-            training_or_seminars = $14,
-            leadership_conferences = $15,
-            leadership_training = $16,
-            others = $17,
-            family_days = $18,
-            tithes_and_offerings = $19,
-            home_visited = $20,
-            bible_study_or_group_led = $21,
-            sermon_or_message_preached = $22,
-            person_newly_contacted = $23,
-            person_followed_up = $24,
-            person_led_to_christ = $25,
-            names = $26,
-            narrative_report = $27,
-            challenges_and_problem_encountered = $28,
-            prayer_request = $29,
-            updated_at = $30
-        WHERE 
-            id = $31
-    `
-
-	// Marshal arrays to JSON
-	worshipServiceJSON, err := json.Marshal(report.WorshipService)
-	if err != nil {
-		return err
-	}
-	sundaySchoolJSON, err := json.Marshal(report.SundaySchool)
-	if err != nil {
-		return err
-	}
-	prayerMeetingsJSON, err := json.Marshal(report.PrayerMeetings)
-	if err != nil {
-		return err
-	}
-	bibleStudiesJSON, err := json.Marshal(report.BibleStudies)
-	if err != nil {
-		return err
-	}
-	mensFellowshipsJSON, err := json.Marshal(report.MensFellowships)
-	if err != nil {
-		return err
-	}
-	womensFellowshipsJSON, err := json.Marshal(report.WomensFellowships)
-	if err != nil {
-		return err
-	}
-	youthFellowshipsJSON, err := json.Marshal(report.YouthFellowships)
-	if err != nil {
-		return err
-	}
-	childFellowshipsJSON, err := json.Marshal(report.ChildFellowships)
-	if err != nil {
-		return err
-	}
-	outreachJSON, err := json.Marshal(report.Outreach)
-	if err != nil {
-		return err
-	}
-	trainingOrSeminarsJSON, err := json.Marshal(report.TrainingOrSeminars)
-	if err != nil {
-		return err
-	}
-	leadershipConferencesJSON, err := json.Marshal(report.LeadershipConferences)
-	if err != nil {
-		return err
-	}
-	leadershipTrainingJSON, err := json.Marshal(report.LeadershipTraining)
-	if err != nil {
-		return err
-	}
-	othersJSON, err := json.Marshal(report.Others)
-	if err != nil {
-		return err
-	}
-	familyDaysJSON, err := json.Marshal(report.FamilyDays)
-	if err != nil {
-		return err
-	}
-	tithesAndOfferingsJSON, err := json.Marshal(report.TithesAndOfferings)
-	if err != nil {
-		return err
-	}
-	homeVisitedJSON, err := json.Marshal(report.HomeVisited)
-	if err != nil {
-		return err
-	}
-	bibleStudyOrGroupLedJSON, err := json.Marshal(report.BibleStudyOrGroupLed)
-	if err != nil {
-		return err
-	}
-	sermonOrMessagePreachedJSON, err := json.Marshal(report.SermonOrMessagePreached)
-	if err != nil {
-		return err
-	}
-	personNewlyContactedJSON, err := json.Marshal(report.PersonNewlyContacted)
-	if err != nil {
-		return err
-	}
-	personFollowedUpJSON, err := json.Marshal(report.PersonFollowedUp)
-	if err != nil {
-		return err
-	}
-	personLedToChristJSON, err := json.Marshal(report.PersonLedToChrist)
+// requireRowAffected turns a write that matched no row into sql.ErrNoRows.
+func requireRowAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
-	namesJSON, err := json.Marshal(report.Names)
-	if err != nil {
-		return err
+	if rows == 0 {
+		return sql.ErrNoRows
 	}
-
-	// Execute the update query
-	_, err = tx.ExecContext(ctx, rawSQL,
-		report.MonthOf,
-		report.WorkerName,
-		report.AreaOfAssignment,
-		report.NameOfChurch,
-		worshipServiceJSON,
-		sundaySchoolJSON,
-		prayerMeetingsJSON,
-		bibleStudiesJSON,
-		mensFellowshipsJSON,
-		womensFellowshipsJSON,
-		youthFellowshipsJSON,
-		childFellowshipsJSON,
-		outreachJSON,
-		trainingOrSeminarsJSON,
-		leadershipConferencesJSON,
-		leadershipTrainingJSON,
-		othersJSON,
-		familyDaysJSON,
-		tithesAndOfferingsJSON,
-		homeVisitedJSON,
-		bibleStudyOrGroupLedJSON,
-		sermonOrMessagePreachedJSON,
-		personNewlyContactedJSON,
-		personFollowedUpJSON,
-		personLedToChristJSON,
-		namesJSON,
-		report.NarrativeReport,
-		report.ChallengesAndProblemEncountered,
-		report.PrayerRequest,
-		report.UpdatedAt,
-		report.Id,
-	)
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 
-func (r *ReportRepositoryImpl) ReportTaken(ctx context.Context, id int, monthOf, workerName string) ([]*model.Report, error) {
-	var reports []*model.Report
-
+// ReportTaken returns the report other than id that worker already filed for
+// monthOf, or nil when there is none.
+func (r *ReportRepositoryImpl) ReportTaken(ctx context.Context, id int, monthOf model.Period, workerId int) (*model.Report, error) {
 	rawSQL := `
 		SELECT
-			id, 
+			id,
 			month_of,
-			worker_name
+			worker_id
 		FROM reports
-		WHERE month_of = $1 OR
-		worker_name = $2
+		WHERE worker_id = $1
+			AND month_of = $2
+			AND id <> $3
+			AND deleted_at IS NULL
+		LIMIT 1
 	`
 
-	rows, err := r.Db.QueryContext(ctx, rawSQL, monthOf, workerName)
+	var report model.Report
+	err := r.Db.QueryRowContext(ctx, rawSQL, workerId, monthOf, id).Scan(
+		&report.Id,
+		&report.MonthOf,
+		&report.WorkerId,
+	)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var report model.Report
-		if err := rows.Scan(
-			&report.Id,
-			&report.MonthOf,
-			&report.WorkerName,
-		); err != nil {
-			return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
 		}
-		reports = append(reports, &report)
+		return nil, err
 	}
 
-	return reports, nil
+	return &report, nil
 }
@@ -1 +1,224 @@
 package repository
+
+import (
+	"errors"
+	"reflect"
+	"reports/model"
+	"strings"
+	"testing"
+)
+
+func TestJSONColumnScan(t *testing.T) {
+	var report model.Report
+	targets := scanTargets(reportColumns, &report)
+
+	activities := targets[columnIndex(t, "t.activities")].(*jsonColumn)
+	if err := activities.Scan([]byte(`{"worship_service": [10, 12]}`)); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if want := (model.ActivityValues{"worship_service": {10, 12}}); !reflect.DeepEqual(report.Activities, want) {
+		t.Fatalf("activities = %v, want %v", report.Activities, want)
+	}
+
+	names := targets[columnIndex(t, "t.names")].(*jsonColumn)
+	err := names.Scan([]byte(`{"not": "a list"}`))
+
+	var malformed *MalformedJSONError
+	if !errors.As(err, &malformed) || malformed.Column != "names" {
+		t.Fatalf("Scan() error = %v, want a *MalformedJSONError for names", err)
+	}
+}
+
+func TestWrittenColumns(t *testing.T) {
+	report := &model.Report{Id: 7, WorkerId: 3, NarrativeReport: "ok"}
+
+	inserted, values := writtenColumns(report, false)
+	if len(inserted) != len(values) {
+		t.Fatalf("%d columns but %d values", len(inserted), len(values))
+	}
+	for i, column := range inserted {
+		if column == "worker_id" && values[i] != 3 {
+			t.Fatalf("worker_id value = %v, want 3", values[i])
+		}
+	}
+
+	updated, _ := writtenColumns(report, true)
+	for _, column := range updated {
+		switch column {
+		case "created_at", "status", "submitted_at":
+			t.Fatalf("Update writes %s", column)
+		}
+	}
+	if len(updated) >= len(inserted) {
+		t.Fatalf("Update writes %d columns, Save %d", len(updated), len(inserted))
+	}
+}
+
+func columnIndex(t *testing.T, expr string) int {
+	for i, column := range reportColumns {
+		if column.expr == expr {
+			return i
+		}
+	}
+	t.Fatalf("no column %s", expr)
+	return -1
+}
+
+func TestSelectedColumns(t *testing.T) {
+	var keys []string
+	for _, column := range reportColumns {
+		keys = append(keys, column.key)
+	}
+	if !reflect.DeepEqual(keys, model.ReportFields) {
+		t.Fatalf("column keys = %v, want model.ReportFields", keys)
+	}
+
+	columns := selectedColumns([]string{"worker_name", "month_of"})
+	if got := reportSelectList(columns); got != "t.id,\n\t\t\tt.month_of,\n\t\t\tw.name" {
+		t.Fatalf("reportSelectList() = %q", got)
+	}
+}
+
+func TestReportOrderBy(t *testing.T) {
+	for _, field := range model.ReportSortFields {
+		if _, ok := reportSortColumns[field]; !ok {
+			t.Fatalf("sort field %s has no column", field)
+		}
+	}
+
+	query := &model.SearchReportQuery{Sort: []model.ReportSort{
+		{Field: "month_of", Desc: true},
+		{Activity: "outreach", Measure: model.MeasureTotal},
+	}}
+
+	orderBy, params, err := reportOrderBy(query, []interface{}{"earlier"})
+	if err != nil {
+		t.Fatalf("reportOrderBy() error = %v", err)
+	}
+	if want := "t.month_of DESC NULLS LAST, " + metricSQL(2) + ", t.id"; orderBy != want {
+		t.Fatalf("reportOrderBy() = %q, want %q", orderBy, want)
+	}
+	if want := []interface{}{"earlier", "outreach", model.MeasureTotal}; !reflect.DeepEqual(params, want) {
+		t.Fatalf("params = %v, want %v", params, want)
+	}
+}
+
+func TestReportSearchConditions(t *testing.T) {
+	query := &model.SearchReportQuery{Search: "typhoon or flood", Status: model.ReportStatusDraft}
+
+	conditions, params := reportConditions(query)
+	if want := "t.search_vector @@ " + searchQuerySQL(len(params)); conditions[len(conditions)-1] != want {
+		t.Fatalf("last condition = %q, want %q", conditions[len(conditions)-1], want)
+	}
+	if params[len(params)-1] != "typhoon or flood" {
+		t.Fatalf("params = %v", params)
+	}
+
+	orderBy, _, err := reportOrderBy(query, params)
+	if err != nil {
+		t.Fatalf("reportOrderBy() error = %v", err)
+	}
+	if want := "ts_rank(t.search_vector, " + searchQuerySQL(len(params)+1) + ") DESC, t.id"; orderBy != want {
+		t.Fatalf("reportOrderBy() = %q, want %q", orderBy, want)
+	}
+}
+
+func TestReportPageSQL(t *testing.T) {
+	columns := selectedColumns(nil)
+
+	rawSQL, params, err := reportPageSQL(&model.SearchReportQuery{Status: model.ReportStatusDraft, Page: 3, PerPage: 20}, columns)
+	if err != nil {
+		t.Fatalf("reportPageSQL() error = %v", err)
+	}
+	if !strings.HasSuffix(rawSQL, " ORDER BY t.id LIMIT $2 OFFSET $3") || strings.Contains(rawSQL, "t.id >") {
+		t.Fatalf("page query = %q, want offset paging", rawSQL)
+	}
+	if want := []interface{}{model.ReportStatusDraft, 21, 40}; !reflect.DeepEqual(params, want) {
+		t.Fatalf("params = %v, want %v", params, want)
+	}
+
+	rawSQL, params, err = reportPageSQL(&model.SearchReportQuery{Status: model.ReportStatusDraft, After: 120, PerPage: 20}, columns)
+	if err != nil {
+		t.Fatalf("reportPageSQL() error = %v", err)
+	}
+	if !strings.HasSuffix(rawSQL, " AND t.id > $2 ORDER BY t.id LIMIT $3") {
+		t.Fatalf("keyset query = %q, want the page after the id without an offset", rawSQL)
+	}
+	if want := []interface{}{model.ReportStatusDraft, 120, 21}; !reflect.DeepEqual(params, want) {
+		t.Fatalf("params = %v, want %v", params, want)
+	}
+
+	if _, _, err := reportPageSQL(&model.SearchReportQuery{After: 120, PerPage: 20, Sort: []model.ReportSort{{Field: "month_of"}}}, columns); err == nil {
+		t.Fatalf("reportPageSQL() with after and sort error = nil, want an error")
+	}
+}
+
+func TestReportPage(t *testing.T) {
+	reports := func(ids ...int) []*model.Report {
+		list := []*model.Report{}
+		for _, id := range ids {
+			list = append(list, &model.Report{Id: id})
+		}
+		return list
+	}
+
+	tests := []struct {
+		name      string
+		query     model.SearchReportQuery
+		total     int
+		read      []*model.Report
+		pages     int
+		listed    int
+		hasNext   bool
+		nextAfter int
+	}{
+		{"first of several pages", model.SearchReportQuery{Page: 1, PerPage: 2}, 5, reports(1, 2, 3), 3, 2, true, 0},
+		{"last page", model.SearchReportQuery{Page: 3, PerPage: 2}, 5, reports(5), 3, 1, false, 0},
+		{"exact multiple, last page", model.SearchReportQuery{Page: 2, PerPage: 2}, 4, reports(3, 4), 2, 2, false, 0},
+		{"exact multiple, middle page", model.SearchReportQuery{Page: 1, PerPage: 2}, 4, reports(1, 2, 3), 2, 2, true, 0},
+		{"no reports", model.SearchReportQuery{Page: 1, PerPage: 2}, 0, reports(), 0, 0, false, 0},
+		{"after with more", model.SearchReportQuery{After: 10, PerPage: 2}, 5, reports(11, 14, 15), 3, 2, true, 14},
+		{"after at the end", model.SearchReportQuery{After: 14, PerPage: 2}, 5, reports(15), 3, 1, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := reportPage(&tt.query, tt.total, tt.read)
+			if result.TotalPages != tt.pages || len(result.Reports) != tt.listed || result.HasNext != tt.hasNext || result.NextAfter != tt.nextAfter {
+				t.Fatalf("reportPage() = %d pages, %d reports, has next %v, next after %d; want %d, %d, %v, %d",
+					result.TotalPages, len(result.Reports), result.HasNext, result.NextAfter, tt.pages, tt.listed, tt.hasNext, tt.nextAfter)
+			}
+		})
+	}
+}
+
+// snippetRow answers a search result scan with the given snippets and
+// leaves the report columns and rank unset.
+type snippetRow []string
+
+func (r snippetRow) Scan(dest ...interface{}) error {
+	for i, snippet := range r {
+		*dest[len(dest)-len(r)+i].(*string) = snippet
+	}
+	return nil
+}
+
+func TestScanSearchResultEscapesSnippets(t *testing.T) {
+	row := snippetRow{
+		"we prayed for the " + matchStart + "typhoon" + matchStop + ` victims <img src=x onerror="alert(1)">`,
+		"no match <b>here</b>",
+		"",
+	}
+
+	report, err := scanSearchResult(row, selectedColumns([]string{"month_of"}))
+	if err != nil {
+		t.Fatalf("scanSearchResult() error = %v", err)
+	}
+
+	want := map[string]string{
+		"narrative_report": "we prayed for the <mark>typhoon</mark> victims &lt;img src=x onerror=&#34;alert(1)&#34;&gt;",
+	}
+	if !reflect.DeepEqual(report.Search.Highlights, want) {
+		t.Fatalf("highlights = %q, want %q", report.Search.Highlights, want)
+	}
+}
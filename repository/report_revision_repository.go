@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"reports/model"
+)
+
+type ReportRevisionRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, revision *model.ReportRevision) error
+	FindByReportId(ctx context.Context, reportId int) ([]*model.ReportRevision, error)
+	FindByRevision(ctx context.Context, reportId, revision int) (*model.ReportRevision, error)
+}
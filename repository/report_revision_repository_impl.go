@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"reports/model"
+)
+
+type ReportRevisionRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewReportRevisionRepository(Db *sql.DB) ReportRevisionRepository {
+	return &ReportRevisionRepositoryImpl{Db: Db}
+}
+
+// Save appends revision to the report's history, numbering it after the
+// latest revision of the same report. It runs in tx, the transaction that
+// wrote the report, so the change and its revision are stored together.
+func (r *ReportRevisionRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, revision *model.ReportRevision) error {
+	snapshotJSON, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	changesJSON, err := json.Marshal(revision.Changes)
+	if err != nil {
+		return err
+	}
+
+	// Serialize writers of the same report so revision numbers stay gapless
+	_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, revision.ReportId)
+	if err != nil {
+		return err
+	}
+
+	rawSQL := `
+		INSERT INTO report_revisions (
+			report_id,
+			revision,
+			action,
+			actor_id,
+			actor_username,
+			snapshot,
+			changes,
+			created_at
+		) VALUES (
+			$1,
+			(SELECT COALESCE(MAX(revision), 0) + 1 FROM report_revisions WHERE report_id = $1),
+			$2, NULLIF($3, 0), $4, $5, $6, $7
+		)
+		RETURNING id, revision
+	`
+
+	err = tx.QueryRowContext(ctx, rawSQL,
+		revision.ReportId,
+		revision.Action,
+		revision.ActorId,
+		revision.ActorUsername,
+		snapshotJSON,
+		changesJSON,
+		revision.CreatedAt,
+	).Scan(&revision.Id, &revision.Revision)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindByReportId lists the history of a report, oldest first, without the
+// snapshots.
+func (r *ReportRevisionRepositoryImpl) FindByReportId(ctx context.Context, reportId int) ([]*model.ReportRevision, error) {
+	rawSQL := `
+		SELECT
+			id,
+			report_id,
+			revision,
+			action,
+			COALESCE(actor_id, 0),
+			actor_username,
+			changes,
+			created_at
+		FROM report_revisions
+		WHERE report_id = $1
+		ORDER BY revision
+	`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL, reportId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*model.ReportRevision{}
+	for rows.Next() {
+		var revision model.ReportRevision
+		var changesJSON []byte
+
+		if err := rows.Scan(
+			&revision.Id,
+			&revision.ReportId,
+			&revision.Revision,
+			&revision.Action,
+			&revision.ActorId,
+			&revision.ActorUsername,
+			&changesJSON,
+			&revision.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(changesJSON, &revision.Changes); err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, &revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+func (r *ReportRevisionRepositoryImpl) FindByRevision(ctx context.Context, reportId, revisionNumber int) (*model.ReportRevision, error) {
+	rawSQL := `
+		SELECT
+			id,
+			report_id,
+			revision,
+			action,
+			COALESCE(actor_id, 0),
+			actor_username,
+			snapshot,
+			changes,
+			created_at
+		FROM report_revisions
+		WHERE report_id = $1
+			AND revision = $2
+	`
+
+	var revision model.ReportRevision
+	var snapshotJSON, changesJSON []byte
+
+	err := r.Db.QueryRowContext(ctx, rawSQL, reportId, revisionNumber).Scan(
+		&revision.Id,
+		&revision.ReportId,
+		&revision.Revision,
+		&revision.Action,
+		&revision.ActorId,
+		&revision.ActorUsername,
+		&snapshotJSON,
+		&changesJSON,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(snapshotJSON, &revision.Snapshot); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(changesJSON, &revision.Changes); err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
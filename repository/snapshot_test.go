@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"reports/model"
+	"strings"
+	"testing"
+)
+
+// snapshotDriver records the transactions and queries of a connection and
+// answers COUNT(*) with snapshotCount and any other query with no rows.
+type snapshotDriver struct {
+	log *[]string
+}
+
+const snapshotCount = 3
+
+func (d snapshotDriver) Open(name string) (driver.Conn, error) { return snapshotConn(d), nil }
+
+type snapshotConn snapshotDriver
+
+func (c snapshotConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("unexpected prepare of %q", query)
+}
+func (c snapshotConn) Close() error { return nil }
+
+func (c snapshotConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c snapshotConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	*c.log = append(*c.log, fmt.Sprintf("begin isolation=%v read_only=%v", sql.IsolationLevel(opts.Isolation), opts.ReadOnly))
+	return c, nil
+}
+
+func (c snapshotConn) Commit() error {
+	*c.log = append(*c.log, "commit")
+	return nil
+}
+
+func (c snapshotConn) Rollback() error {
+	*c.log = append(*c.log, "rollback")
+	return nil
+}
+
+func (c snapshotConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if strings.HasPrefix(query, "SELECT COUNT(*)") {
+		*c.log = append(*c.log, "count")
+		return &snapshotRows{columns: []string{"count"}, values: [][]driver.Value{{int64(snapshotCount)}}}, nil
+	}
+	*c.log = append(*c.log, "page")
+	return &snapshotRows{columns: make([]string, len(reportColumns))}, nil
+}
+
+type snapshotRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *snapshotRows) Columns() []string { return r.columns }
+func (r *snapshotRows) Close() error      { return nil }
+
+func (r *snapshotRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestFindAllCountsAndPagesInOneSnapshot(t *testing.T) {
+	var log []string
+	sql.Register("repository_test_snapshot", snapshotDriver{log: &log})
+	db, _ := sql.Open("repository_test_snapshot", "")
+	defer db.Close()
+
+	result, err := NewReportRepository(db).FindAll(context.Background(), &model.SearchReportQuery{Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if result.TotalCount != snapshotCount || result.TotalPages != 1 {
+		t.Fatalf("result = %d reports over %d pages, want %d over 1", result.TotalCount, result.TotalPages, snapshotCount)
+	}
+
+	want := []string{"begin isolation=Repeatable Read read_only=true", "count", "page", "commit"}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("database calls = %v, want %v", log, want)
+	}
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"reports/helper"
+	"reports/model"
+)
+
+type UserRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewUserRepository(Db *sql.DB) UserRepository {
+	return &UserRepositoryImpl{Db: Db}
+}
+
+func (r *UserRepositoryImpl) Save(ctx context.Context, user *model.User) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		INSERT INTO users (
+			username,
+			password,
+			role,
+			worker_id,
+			area_id,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5, 0), $6, $7)
+		RETURNING id
+	`
+
+	err = tx.QueryRowContext(ctx, rawSQL,
+		user.Username,
+		user.Password,
+		user.Role,
+		user.WorkerId,
+		user.AreaId,
+		user.CreatedAt,
+		user.UpdatedAt,
+	).Scan(&user.Id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepositoryImpl) FindById(ctx context.Context, userId int) (*model.User, error) {
+	rawSQL := `
+		SELECT
+			id,
+			username,
+			password,
+			role,
+			COALESCE(worker_id, 0),
+			COALESCE(area_id, 0),
+			created_at,
+			updated_at
+		FROM users
+		WHERE id = $1
+	`
+
+	var user model.User
+	err := r.Db.QueryRowContext(ctx, rawSQL, userId).Scan(
+		&user.Id,
+		&user.Username,
+		&user.Password,
+		&user.Role,
+		&user.WorkerId,
+		&user.AreaId,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepositoryImpl) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	rawSQL := `
+		SELECT
+			id,
+			username,
+			password,
+			role,
+			COALESCE(worker_id, 0),
+			COALESCE(area_id, 0),
+			created_at,
+			updated_at
+		FROM users
+		WHERE username = $1
+	`
+
+	var user model.User
+	err := r.Db.QueryRowContext(ctx, rawSQL, username).Scan(
+		&user.Id,
+		&user.Username,
+		&user.Password,
+		&user.Role,
+		&user.WorkerId,
+		&user.AreaId,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
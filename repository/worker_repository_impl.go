@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"reports/helper"
+	"reports/model"
+)
+
+type WorkerRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewWorkerRepository(Db *sql.DB) WorkerRepository {
+	return &WorkerRepositoryImpl{Db: Db}
+}
+
+func (r *WorkerRepositoryImpl) Save(ctx context.Context, worker *model.Worker) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		INSERT INTO workers (
+			name,
+			area_id,
+			church_id,
+			active,
+			created_at,
+			updated_at
+		) VALUES ($1, NULLIF($2, 0), NULLIF($3, 0), $4, $5, $6)
+		RETURNING id
+	`
+
+	return tx.QueryRowContext(ctx, rawSQL,
+		worker.Name,
+		worker.AreaId,
+		worker.ChurchId,
+		worker.Active,
+		worker.CreatedAt,
+		worker.UpdatedAt,
+	).Scan(&worker.Id)
+}
+
+func (r *WorkerRepositoryImpl) Update(ctx context.Context, worker *model.Worker) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		UPDATE workers SET
+			name = $1,
+			area_id = NULLIF($2, 0),
+			church_id = NULLIF($3, 0),
+			active = $4,
+			updated_at = $5
+		WHERE id = $6
+	`
+
+	_, err = tx.ExecContext(ctx, rawSQL,
+		worker.Name,
+		worker.AreaId,
+		worker.ChurchId,
+		worker.Active,
+		worker.UpdatedAt,
+		worker.Id,
+	)
+	return err
+}
+
+func (r *WorkerRepositoryImpl) Delete(ctx context.Context, workerId int) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		DELETE FROM workers
+		WHERE id = $1
+	`
+
+	_, err = tx.ExecContext(ctx, rawSQL, workerId)
+	return err
+}
+
+func (r *WorkerRepositoryImpl) FindById(ctx context.Context, workerId int) (*model.Worker, error) {
+	rawSQL := `
+		SELECT
+			w.id,
+			w.name,
+			COALESCE(w.area_id, 0),
+			COALESCE(a.name, ''),
+			COALESCE(w.church_id, 0),
+			COALESCE(c.name, ''),
+			w.active,
+			w.created_at,
+			w.updated_at
+		FROM workers w
+		LEFT JOIN areas a ON a.id = w.area_id
+		LEFT JOIN churches c ON c.id = w.church_id
+		WHERE w.id = $1
+	`
+
+	var worker model.Worker
+	err := r.Db.QueryRowContext(ctx, rawSQL, workerId).Scan(
+		&worker.Id,
+		&worker.Name,
+		&worker.AreaId,
+		&worker.AreaName,
+		&worker.ChurchId,
+		&worker.ChurchName,
+		&worker.Active,
+		&worker.CreatedAt,
+		&worker.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &worker, nil
+}
+
+// FindByName finds a worker by name, ignoring case.
+func (r *WorkerRepositoryImpl) FindByName(ctx context.Context, name string) (*model.Worker, error) {
+	rawSQL := `
+		SELECT
+			w.id,
+			w.name,
+			COALESCE(w.area_id, 0),
+			COALESCE(a.name, ''),
+			COALESCE(w.church_id, 0),
+			COALESCE(c.name, ''),
+			w.active,
+			w.created_at,
+			w.updated_at
+		FROM workers w
+		LEFT JOIN areas a ON a.id = w.area_id
+		LEFT JOIN churches c ON c.id = w.church_id
+		WHERE LOWER(w.name) = LOWER($1)
+	`
+
+	var worker model.Worker
+	err := r.Db.QueryRowContext(ctx, rawSQL, name).Scan(
+		&worker.Id,
+		&worker.Name,
+		&worker.AreaId,
+		&worker.AreaName,
+		&worker.ChurchId,
+		&worker.ChurchName,
+		&worker.Active,
+		&worker.CreatedAt,
+		&worker.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &worker, nil
+}
+
+// FindAll lists workers, limited to one area when areaId is set.
+func (r *WorkerRepositoryImpl) FindAll(ctx context.Context, areaId int) ([]*model.Worker, error) {
+	rawSQL := `
+		SELECT
+			w.id,
+			w.name,
+			COALESCE(w.area_id, 0),
+			COALESCE(a.name, ''),
+			COALESCE(w.church_id, 0),
+			COALESCE(c.name, ''),
+			w.active,
+			w.created_at,
+			w.updated_at
+		FROM workers w
+		LEFT JOIN areas a ON a.id = w.area_id
+		LEFT JOIN churches c ON c.id = w.church_id
+		WHERE $1 = 0 OR w.area_id = $1
+		ORDER BY w.name
+	`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL, areaId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workers := []*model.Worker{}
+	for rows.Next() {
+		var worker model.Worker
+		if err := rows.Scan(
+			&worker.Id,
+			&worker.Name,
+			&worker.AreaId,
+			&worker.AreaName,
+			&worker.ChurchId,
+			&worker.ChurchName,
+			&worker.Active,
+			&worker.CreatedAt,
+			&worker.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		workers = append(workers, &worker)
+	}
+
+	return workers, rows.Err()
+}
@@ -7,23 +7,85 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewRouter(reportController *controller.ReportController) *gin.Engine {
+func NewRouter(
+	authMiddleware gin.HandlerFunc,
+	authController *controller.AuthController,
+	areaController *controller.AreaController,
+	churchController *controller.ChurchController,
+	workerController *controller.WorkerController,
+	reportController *controller.ReportController,
+	activityController *controller.ActivityController,
+	analyticsController *controller.AnalyticsController,
+) *gin.Engine {
 	service := gin.Default()
 
 	service.GET("/", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, "Welcome Home!")
 	})
 
+	// Auth Group
+	authRouter := service.Group("/auth")
+
+	authRouter.POST("/login", authController.Login)
+	authRouter.POST("/logout", authController.Logout)
+	authRouter.POST("/register", authMiddleware, authController.Register)
+	authRouter.GET("/me", authMiddleware, authController.Me)
+
 	// Api Group
 	router := service.Group("/api")
+	router.Use(authMiddleware)
 
 	router.GET("", reportController.FindAll)
 	router.POST("", reportController.Create)
+	router.GET("/trash", reportController.Trash)
+	router.GET("/template", reportController.Template)
+	router.POST("/import", reportController.ImportReports)
+	router.POST("/import.csv", reportController.ImportCSV)
+	router.GET("/export", reportController.ExportReports)
+	router.GET("/export/summary", reportController.ExportSummary)
+	router.GET("/export.csv", reportController.ExportCSV)
+	router.GET("/export.ndjson", reportController.ExportNDJSON)
 	router.GET("/:reportId", reportController.FindById)
 	router.PUT("/:reportId", reportController.Update)
 	router.DELETE("/:reportId", reportController.Delete)
+	router.POST("/:reportId/restore", reportController.Restore)
 
 	router.GET("/:reportId/export", reportController.ExportReport)
 
+	router.POST("/:reportId/submit", reportController.Submit)
+	router.POST("/:reportId/approve", reportController.Approve)
+	router.POST("/:reportId/return", reportController.Return)
+
+	router.GET("/:reportId/history", reportController.History)
+	router.GET("/:reportId/history/:revision", reportController.FindRevision)
+	router.POST("/:reportId/history/:revision/restore", reportController.RestoreRevision)
+
+	router.GET("/areas", areaController.FindAll)
+	router.POST("/areas", areaController.Create)
+	router.GET("/areas/:areaId", areaController.FindById)
+	router.PUT("/areas/:areaId", areaController.Update)
+	router.DELETE("/areas/:areaId", areaController.Delete)
+
+	router.GET("/churches", churchController.FindAll)
+	router.POST("/churches", churchController.Create)
+	router.GET("/churches/:churchId", churchController.FindById)
+	router.PUT("/churches/:churchId", churchController.Update)
+	router.DELETE("/churches/:churchId", churchController.Delete)
+
+	router.GET("/activities", activityController.FindAll)
+	router.POST("/activities", activityController.Create)
+	router.GET("/activities/:activityId", activityController.FindById)
+	router.PUT("/activities/:activityId", activityController.Update)
+
+	router.GET("/analytics/summary", analyticsController.Summary)
+	router.GET("/analytics/trends", analyticsController.Trends)
+	router.GET("/analytics/compliance", analyticsController.Compliance)
+
+	router.GET("/workers", workerController.FindAll)
+	router.POST("/workers", workerController.Create)
+	router.GET("/workers/:workerId", workerController.FindById)
+	router.PUT("/workers/:workerId", workerController.Update)
+	router.DELETE("/workers/:workerId", workerController.Delete)
+
 	return service
 }
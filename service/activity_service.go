@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"reports/data/request"
+	"reports/model"
+)
+
+type ActivityService interface {
+	Create(ctx context.Context, request *request.ActivityCreateRequest) (*model.Activity, error)
+	Update(ctx context.Context, request *request.ActivityUpdateRequest) error
+	FindById(ctx context.Context, activityId int) (*model.Activity, error)
+	FindAll(ctx context.Context, includeInactive bool) (model.ActivityCatalog, error)
+}
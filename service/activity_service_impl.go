@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reports/data/request"
+	"reports/helper"
+	"reports/model"
+	"reports/repository"
+	"strings"
+	"time"
+)
+
+type ActivityServiceImpl struct {
+	activityRepository repository.ActivityRepository
+}
+
+func NewActivityServiceImpl(activityRepository repository.ActivityRepository) ActivityService {
+	return &ActivityServiceImpl{activityRepository: activityRepository}
+}
+
+func (a *ActivityServiceImpl) Create(ctx context.Context, request *request.ActivityCreateRequest) (*model.Activity, error) {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	activity := model.Activity{
+		Key:          request.Key,
+		Label:        strings.TrimSpace(request.Label),
+		Category:     request.Category,
+		DisplayOrder: request.DisplayOrder,
+		Active:       request.Active == nil || *request.Active,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := a.activityRepository.Save(ctx, &activity); err != nil {
+		if helper.IsUniqueViolation(err) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to save activity: %w", err)
+	}
+
+	return &activity, nil
+}
+
+// Update changes the label, category, order and active flag of an activity.
+// Reports keep their values under the key, so it cannot be changed.
+func (a *ActivityServiceImpl) Update(ctx context.Context, request *request.ActivityUpdateRequest) error {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return err
+	}
+
+	activity, err := a.activityRepository.FindById(ctx, request.Id)
+	if err != nil {
+		return err
+	}
+
+	if request.Key != "" && request.Key != activity.Key {
+		return ErrActivityKeyLocked
+	}
+
+	activity.Label = strings.TrimSpace(request.Label)
+	activity.Category = request.Category
+	activity.DisplayOrder = request.DisplayOrder
+	if request.Active != nil {
+		activity.Active = *request.Active
+	}
+	activity.UpdatedAt = time.Now().UTC()
+
+	return a.activityRepository.Update(ctx, activity)
+}
+
+func (a *ActivityServiceImpl) FindById(ctx context.Context, activityId int) (*model.Activity, error) {
+	if _, err := requireRole(ctx); err != nil {
+		return nil, err
+	}
+
+	return a.activityRepository.FindById(ctx, activityId)
+}
+
+// FindAll lists the catalog in display order, leaving out retired activities
+// unless includeInactive is set.
+func (a *ActivityServiceImpl) FindAll(ctx context.Context, includeInactive bool) (model.ActivityCatalog, error) {
+	if _, err := requireRole(ctx); err != nil {
+		return nil, err
+	}
+
+	catalog, err := a.activityRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !includeInactive {
+		catalog = catalog.Active()
+	}
+
+	return catalog, nil
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"reports/model"
+)
+
+type AnalyticsService interface {
+	Summary(ctx context.Context, query *model.AnalyticsQuery) (*model.AnalyticsSummary, error)
+	Trends(ctx context.Context, query *model.TrendQuery) (*model.TrendSeries, error)
+	Compliance(ctx context.Context, query *model.ComplianceQuery) (*model.ComplianceReport, error)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reports/helper"
+	"reports/model"
+	"reports/repository"
+)
+
+type AnalyticsServiceImpl struct {
+	analyticsRepository repository.AnalyticsRepository
+	activityRepository  repository.ActivityRepository
+	averageRounding     model.AverageRounding
+
+	// Reports are due this day of the month after the one they cover.
+	deadlineDay int
+}
+
+func NewAnalyticsServiceImpl(analyticsRepository repository.AnalyticsRepository, activityRepository repository.ActivityRepository, averageRounding model.AverageRounding, deadlineDay int) AnalyticsService {
+	return &AnalyticsServiceImpl{
+		analyticsRepository: analyticsRepository,
+		activityRepository:  activityRepository,
+		averageRounding:     averageRounding,
+		deadlineDay:         deadlineDay,
+	}
+}
+
+// Summary adds up the activities of the reports the caller may read: a
+// worker only sees their own reports and an area supervisor their area.
+func (a *AnalyticsServiceImpl) Summary(ctx context.Context, query *model.AnalyticsQuery) (*model.AnalyticsSummary, error) {
+	user, ok := helper.CurrentUser(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	if err := scopeReportQuery(user, &query.Filter); err != nil {
+		return nil, err
+	}
+
+	if query.Period == "" {
+		query.Period = model.PeriodMonth
+	}
+
+	groups, err := a.analyticsRepository.Summary(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum up reports: %w", err)
+	}
+
+	catalog, err := a.activityRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.AnalyticsSummary{
+		GroupBy: query.GroupBy,
+		Totals:  &model.AnalyticsGroup{Activities: map[string]*model.ActivitySummary{}},
+		Groups:  groups,
+	}
+	if summary.GroupBy == nil {
+		summary.GroupBy = []string{}
+	}
+	for _, name := range query.GroupBy {
+		if name == model.GroupByPeriod {
+			summary.Period = query.Period
+		}
+	}
+
+	for _, group := range groups {
+		for key, activity := range group.Activities {
+			// Retired activities keep their label; values under keys that
+			// were never in the catalog are shown by key
+			activity.Label = key
+			if found := catalog.Find(key); found != nil {
+				activity.Label = found.Label
+			}
+		}
+		group.Finish(a.averageRounding)
+		summary.Totals.Add(group)
+	}
+	summary.Totals.Finish(a.averageRounding)
+
+	return summary, nil
+}
+
+// Trends returns the monthly series of one activity measure with its
+// month-over-month and year-over-year changes, within the reports the
+// caller may read.
+func (a *AnalyticsServiceImpl) Trends(ctx context.Context, query *model.TrendQuery) (*model.TrendSeries, error) {
+	user, ok := helper.CurrentUser(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	if err := scopeReportQuery(user, &query.Filter); err != nil {
+		return nil, err
+	}
+
+	catalog, err := a.activityRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	activity := catalog.Find(query.Activity)
+	if activity == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownActivity, query.Activity)
+	}
+
+	// The year before the range is read too, for the first changes
+	months, err := a.analyticsRepository.MonthlyActivity(ctx, &query.Filter, query.Activity, query.From.AddMonths(-12), query.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read monthly activity: %w", err)
+	}
+
+	series := &model.TrendSeries{
+		Activity: activity.Key,
+		Label:    activity.Label,
+		Measure:  query.Measure,
+		From:     query.From,
+		To:       query.To,
+		Points:   model.TrendPoints(months, query.From, query.Measure, a.averageRounding),
+	}
+	series.Scope, series.ScopeId = trendScope(&query.Filter)
+
+	return series, nil
+}
+
+// trendScope names the narrowest of the worker, church and area filters,
+// including the ones the caller's role adds.
+func trendScope(filter *model.SearchReportQuery) (string, int) {
+	switch {
+	case filter.ScopeWorkerId > 0:
+		return model.GroupByWorker, filter.ScopeWorkerId
+	case filter.WorkerId > 0:
+		return model.GroupByWorker, filter.WorkerId
+	case filter.ChurchId > 0:
+		return model.GroupByChurch, filter.ChurchId
+	case filter.AreaId > 0:
+		return model.GroupByArea, filter.AreaId
+	case filter.ScopeAreaId > 0:
+		return model.GroupByArea, filter.ScopeAreaId
+	}
+	return model.ScopeNational, 0
+}
+
+// Compliance tells which active workers submitted their report for the
+// month and how timely they are. Area supervisors only see their area.
+func (a *AnalyticsServiceImpl) Compliance(ctx context.Context, query *model.ComplianceQuery) (*model.ComplianceReport, error) {
+	user, err := requireRole(ctx, model.RoleNationalAdmin, model.RoleAreaSupervisor)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == model.RoleAreaSupervisor {
+		if user.AreaId <= 0 || (query.AreaId > 0 && query.AreaId != user.AreaId) {
+			return nil, ErrForbidden
+		}
+		query.AreaId = user.AreaId
+	}
+
+	roster, err := a.analyticsRepository.Roster(ctx, query.AreaId, query.Month.AddMonths(1-query.History), query.Month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the roster: %w", err)
+	}
+
+	return model.BuildCompliance(roster, query, a.deadlineDay, model.Manila, a.averageRounding), nil
+}
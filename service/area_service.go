@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"reports/data/request"
+	"reports/model"
+)
+
+type AreaService interface {
+	Create(ctx context.Context, request *request.AreaCreateRequest) (*model.Area, error)
+	Update(ctx context.Context, request *request.AreaUpdateRequest) error
+	Delete(ctx context.Context, areaId int) error
+	FindById(ctx context.Context, areaId int) (*model.Area, error)
+	FindAll(ctx context.Context) ([]*model.Area, error)
+}
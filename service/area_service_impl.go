@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reports/data/request"
+	"reports/helper"
+	"reports/model"
+	"reports/repository"
+	"time"
+)
+
+type AreaServiceImpl struct {
+	areaRepository repository.AreaRepository
+}
+
+func NewAreaServiceImpl(areaRepository repository.AreaRepository) AreaService {
+	return &AreaServiceImpl{areaRepository: areaRepository}
+}
+
+func (a *AreaServiceImpl) Create(ctx context.Context, request *request.AreaCreateRequest) (*model.Area, error) {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	area := model.Area{
+		Name:      model.NormalizeName(request.Name),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := a.areaRepository.Save(ctx, &area); err != nil {
+		if helper.IsUniqueViolation(err) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to save area: %w", err)
+	}
+
+	return &area, nil
+}
+
+func (a *AreaServiceImpl) Update(ctx context.Context, request *request.AreaUpdateRequest) error {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return err
+	}
+
+	area, err := a.areaRepository.FindById(ctx, request.Id)
+	if err != nil {
+		return err
+	}
+
+	area.Name = model.NormalizeName(request.Name)
+	area.UpdatedAt = time.Now().UTC()
+
+	if err := a.areaRepository.Update(ctx, area); err != nil {
+		if helper.IsUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (a *AreaServiceImpl) Delete(ctx context.Context, areaId int) error {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return err
+	}
+
+	area, err := a.areaRepository.FindById(ctx, areaId)
+	if err != nil {
+		return err
+	}
+
+	if err := a.areaRepository.Delete(ctx, area.Id); err != nil {
+		if helper.IsForeignKeyViolation(err) {
+			return ErrInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (a *AreaServiceImpl) FindById(ctx context.Context, areaId int) (*model.Area, error) {
+	if _, err := requireRole(ctx); err != nil {
+		return nil, err
+	}
+
+	return a.areaRepository.FindById(ctx, areaId)
+}
+
+func (a *AreaServiceImpl) FindAll(ctx context.Context) ([]*model.Area, error) {
+	if _, err := requireRole(ctx); err != nil {
+		return nil, err
+	}
+
+	return a.areaRepository.FindAll(ctx)
+}
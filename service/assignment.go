@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reports/model"
+	"reports/repository"
+)
+
+// checkChurchInArea checks that the church, when set, exists and belongs to
+// the area, and returns it.
+func checkChurchInArea(ctx context.Context, churchRepository repository.ChurchRepository, churchId, areaId int) (*model.Church, error) {
+	if churchId <= 0 {
+		return nil, nil
+	}
+
+	church, err := churchRepository.FindById(ctx, churchId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: church %d", ErrReferenceNotFound, churchId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if church.AreaId != areaId {
+		return nil, ErrChurchNotInArea
+	}
+
+	return church, nil
+}
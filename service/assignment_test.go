@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reports/model"
+	"reports/repository"
+	"testing"
+)
+
+type stubWorkers struct {
+	repository.WorkerRepository
+	workers map[int]*model.Worker
+}
+
+func (s stubWorkers) FindById(ctx context.Context, id int) (*model.Worker, error) {
+	if worker, ok := s.workers[id]; ok {
+		return worker, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s stubWorkers) FindByName(ctx context.Context, name string) (*model.Worker, error) {
+	for _, worker := range s.workers {
+		if worker.Name == name {
+			return worker, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+type stubChurches struct {
+	repository.ChurchRepository
+	churches map[int]*model.Church
+}
+
+func (s stubChurches) FindById(ctx context.Context, id int) (*model.Church, error) {
+	if church, ok := s.churches[id]; ok {
+		return church, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s stubChurches) FindByName(ctx context.Context, areaId int, name string) (*model.Church, error) {
+	for _, church := range s.churches {
+		if church.AreaId == areaId && church.Name == name {
+			return church, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+type stubAreas struct {
+	repository.AreaRepository
+	areas map[int]*model.Area
+}
+
+func (s stubAreas) FindById(ctx context.Context, id int) (*model.Area, error) {
+	if area, ok := s.areas[id]; ok {
+		return area, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s stubAreas) FindByName(ctx context.Context, name string) (*model.Area, error) {
+	for _, area := range s.areas {
+		if area.Name == name {
+			return area, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestCheckAssignment(t *testing.T) {
+	const luzon, mindanao = 1, 2
+
+	service := &ReportServiceImpl{
+		workerRepository: stubWorkers{workers: map[int]*model.Worker{10: {Id: 10, AreaId: luzon}}},
+		churchRepository: stubChurches{churches: map[int]*model.Church{
+			100: {Id: 100, AreaId: luzon},
+			200: {Id: 200, AreaId: mindanao},
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		report *model.Report
+		before *model.Report
+		want   error
+	}{
+		{"assigned area and church", &model.Report{WorkerId: 10, AreaId: luzon, ChurchId: 100}, nil, nil},
+		{"other area", &model.Report{WorkerId: 10, AreaId: mindanao, ChurchId: 200}, nil, ErrAreaNotAssigned},
+		{"church of other area", &model.Report{WorkerId: 10, AreaId: luzon, ChurchId: 200}, nil, ErrChurchNotInArea},
+		{"unknown worker", &model.Report{WorkerId: 99, AreaId: luzon}, nil, ErrReferenceNotFound},
+		{"unknown church", &model.Report{WorkerId: 10, AreaId: luzon, ChurchId: 999}, nil, ErrReferenceNotFound},
+		{"unchanged after the worker moved", &model.Report{WorkerId: 10, AreaId: mindanao, ChurchId: 200},
+			&model.Report{WorkerId: 10, AreaId: mindanao, ChurchId: 200}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := service.checkAssignment(context.Background(), test.report, test.before)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("checkAssignment() = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
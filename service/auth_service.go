@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"reports/data/request"
+	"reports/model"
+)
+
+type AuthService interface {
+	Login(ctx context.Context, request *request.LoginRequest) (string, error)
+	Register(ctx context.Context, request *request.RegisterRequest) (*model.User, error)
+	FindUserById(ctx context.Context, userId int) (*model.User, error)
+	EnsureAdmin(ctx context.Context, username, password string) error
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reports/config"
+	"reports/data/request"
+	"reports/helper"
+	"reports/model"
+	"reports/repository"
+	"reports/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type AuthServiceImpl struct {
+	userRepository repository.UserRepository
+	config         *config.Config
+}
+
+func NewAuthServiceImpl(userRepository repository.UserRepository, config *config.Config) AuthService {
+	return &AuthServiceImpl{userRepository: userRepository, config: config}
+}
+
+func (a *AuthServiceImpl) Login(ctx context.Context, request *request.LoginRequest) (string, error) {
+	user, err := a.userRepository.FindByUsername(ctx, request.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrInvalidCredentials
+		}
+		return "", err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	token, err := utils.GenerateToken(a.config.TokenExpiresIn, strconv.Itoa(user.Id), a.config.TokenSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (a *AuthServiceImpl) Register(ctx context.Context, request *request.RegisterRequest) (*model.User, error) {
+	user, ok := helper.CurrentUser(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	if user.Role != model.RoleNationalAdmin {
+		return nil, ErrForbidden
+	}
+
+	return a.createUser(ctx, &model.User{
+		Username: request.Username,
+		Role:     request.Role,
+		WorkerId: request.WorkerId,
+		AreaId:   request.AreaId,
+	}, request.Password)
+}
+
+func (a *AuthServiceImpl) FindUserById(ctx context.Context, userId int) (*model.User, error) {
+	return a.userRepository.FindById(ctx, userId)
+}
+
+// knownPasswords are defaults from examples and old env files that must
+// never guard a national admin account.
+var knownPasswords = map[string]bool{
+	"admin":            true,
+	"password":         true,
+	"secret":           true,
+	"change-me-please": true,
+	"changeme":         true,
+}
+
+// EnsureAdmin creates the bootstrap account from the config when it does not
+// exist yet, so a fresh database always has someone who can log in. It
+// refuses to create it with a known default or short password.
+func (a *AuthServiceImpl) EnsureAdmin(ctx context.Context, username, password string) error {
+	if username == "" || password == "" {
+		return nil
+	}
+
+	_, err := a.userRepository.FindByUsername(ctx, username)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if len(password) < 8 || knownPasswords[strings.ToLower(password)] {
+		return ErrWeakAdminPassword
+	}
+
+	_, err = a.createUser(ctx, &model.User{Username: username, Role: model.RoleNationalAdmin}, password)
+	return err
+}
+
+func (a *AuthServiceImpl) createUser(ctx context.Context, user *model.User, password string) (*model.User, error) {
+	_, err := a.userRepository.FindByUsername(ctx, user.Username)
+	if err == nil {
+		return nil, ErrUsernameTaken
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	user.Password = string(hashedPassword)
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	if err := a.userRepository.Save(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	return user, nil
+}
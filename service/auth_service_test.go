@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reports/config"
+	"reports/data/request"
+	"reports/model"
+	"reports/repository"
+	"reports/utils"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type stubUsers struct {
+	repository.UserRepository
+	users map[string]*model.User
+}
+
+func (s *stubUsers) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	if user, ok := s.users[username]; ok {
+		return user, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *stubUsers) Save(ctx context.Context, user *model.User) error {
+	user.Id = len(s.users) + 1
+	s.users[user.Username] = user
+	return nil
+}
+
+func TestEnsureAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing bool
+		username string
+		password string
+		want     error
+		created  bool
+	}{
+		{"creates the admin", false, "admin", "a-long-passphrase", nil, true},
+		{"keeps an existing admin", true, "admin", "a-long-passphrase", nil, false},
+		{"nothing configured", false, "admin", "", nil, false},
+		{"known default", false, "admin", "change-me-please", ErrWeakAdminPassword, false},
+		{"known default in capitals", false, "admin", "PASSWORD", ErrWeakAdminPassword, false},
+		{"short password", false, "admin", "short", ErrWeakAdminPassword, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &stubUsers{users: map[string]*model.User{}}
+			if tt.existing {
+				users.users["admin"] = &model.User{Id: 1, Username: "admin", Role: model.RoleNationalAdmin}
+			}
+			service := NewAuthServiceImpl(users, &config.Config{})
+
+			err := service.EnsureAdmin(context.Background(), tt.username, tt.password)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("EnsureAdmin() error = %v, want %v", err, tt.want)
+			}
+
+			user, ok := users.users[tt.username]
+			if created := ok && !tt.existing; created != tt.created {
+				t.Fatalf("admin created = %v, want %v", created, tt.created)
+			}
+			if tt.created {
+				if user.Role != model.RoleNationalAdmin {
+					t.Errorf("role = %q, want %q", user.Role, model.RoleNationalAdmin)
+				}
+				if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(tt.password)) != nil {
+					t.Errorf("stored password does not match")
+				}
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("a-long-passphrase"), bcrypt.MinCost)
+	users := &stubUsers{users: map[string]*model.User{"admin": {Id: 7, Username: "admin", Password: string(hashed)}}}
+	cfg := &config.Config{TokenSecret: "test-secret", TokenExpiresIn: time.Hour}
+	service := NewAuthServiceImpl(users, cfg)
+
+	token, err := service.Login(context.Background(), &request.LoginRequest{Username: "admin", Password: "a-long-passphrase"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if sub, err := utils.ValidateToken(token, cfg.TokenSecret); err != nil || sub != "7" {
+		t.Fatalf("token subject = %q, %v, want 7", sub, err)
+	}
+
+	for _, req := range []request.LoginRequest{
+		{Username: "admin", Password: "wrong-passphrase"},
+		{Username: "nobody", Password: "a-long-passphrase"},
+	} {
+		if _, err := service.Login(context.Background(), &req); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("Login(%q) error = %v, want %v", req.Username, err, ErrInvalidCredentials)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"reports/data/request"
+	"reports/model"
+)
+
+type ChurchService interface {
+	Create(ctx context.Context, request *request.ChurchCreateRequest) (*model.Church, error)
+	Update(ctx context.Context, request *request.ChurchUpdateRequest) error
+	Delete(ctx context.Context, churchId int) error
+	FindById(ctx context.Context, churchId int) (*model.Church, error)
+	FindAll(ctx context.Context, areaId int) ([]*model.Church, error)
+}
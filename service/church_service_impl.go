@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reports/data/request"
+	"reports/helper"
+	"reports/model"
+	"reports/repository"
+	"time"
+)
+
+type ChurchServiceImpl struct {
+	churchRepository repository.ChurchRepository
+}
+
+func NewChurchServiceImpl(churchRepository repository.ChurchRepository) ChurchService {
+	return &ChurchServiceImpl{churchRepository: churchRepository}
+}
+
+func (c *ChurchServiceImpl) Create(ctx context.Context, request *request.ChurchCreateRequest) (*model.Church, error) {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	church := model.Church{
+		Name:      model.NormalizeName(request.Name),
+		AreaId:    request.AreaId,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := c.churchRepository.Save(ctx, &church); err != nil {
+		if helper.IsUniqueViolation(err) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to save church: %w", err)
+	}
+
+	return c.churchRepository.FindById(ctx, church.Id)
+}
+
+func (c *ChurchServiceImpl) Update(ctx context.Context, request *request.ChurchUpdateRequest) error {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return err
+	}
+
+	church, err := c.churchRepository.FindById(ctx, request.Id)
+	if err != nil {
+		return err
+	}
+
+	church.Name = model.NormalizeName(request.Name)
+	church.AreaId = request.AreaId
+	church.UpdatedAt = time.Now().UTC()
+
+	if err := c.churchRepository.Update(ctx, church); err != nil {
+		if helper.IsUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (c *ChurchServiceImpl) Delete(ctx context.Context, churchId int) error {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return err
+	}
+
+	church, err := c.churchRepository.FindById(ctx, churchId)
+	if err != nil {
+		return err
+	}
+
+	if err := c.churchRepository.Delete(ctx, church.Id); err != nil {
+		if helper.IsForeignKeyViolation(err) {
+			return ErrInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (c *ChurchServiceImpl) FindById(ctx context.Context, churchId int) (*model.Church, error) {
+	if _, err := requireRole(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.churchRepository.FindById(ctx, churchId)
+}
+
+func (c *ChurchServiceImpl) FindAll(ctx context.Context, areaId int) ([]*model.Church, error) {
+	if _, err := requireRole(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.churchRepository.FindAll(ctx, areaId)
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnauthenticated    = errors.New("not authenticated")
+	ErrForbidden          = errors.New("you are not allowed to perform this action")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrUsernameTaken      = errors.New("username already taken")
+	ErrAlreadyExists      = errors.New("a record with the same name already exists")
+	ErrInUse              = errors.New("record is still referenced by other records")
+	ErrChurchNotInArea    = errors.New("church does not belong to the given area")
+	ErrAreaNotAssigned    = errors.New("area is not the worker's area of assignment")
+	ErrReferenceNotFound  = errors.New("referenced record does not exist")
+	ErrReportTaken        = errors.New("worker already filed a report for this month")
+	ErrReportLocked       = errors.New("approved reports can no longer be edited")
+	ErrInvalidTransition  = errors.New("report cannot move to that status from its current status")
+	ErrInvalidImport      = errors.New("file cannot be imported")
+	ErrWorkerRequired     = errors.New("worker must not be empty")
+	ErrUnknownActivity    = errors.New("activity is not in the catalog")
+	ErrInactiveActivity   = errors.New("activity is no longer active")
+	ErrActivityKeyLocked  = errors.New("activity key cannot be changed")
+	ErrWeakAdminPassword  = errors.New("admin password is a known default or shorter than 8 characters")
+)
+
+// ReportConflictError is returned when the worker already filed a report for
+// the month, naming the report that is in the way.
+type ReportConflictError struct {
+	ExistingId int
+}
+
+func (e *ReportConflictError) Error() string {
+	return fmt.Sprintf("worker already filed report %d for this month", e.ExistingId)
+}
+
+func (e *ReportConflictError) Unwrap() error {
+	return ErrReportTaken
+}
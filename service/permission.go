@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"reports/helper"
+	"reports/model"
+)
+
+// requireRole returns the user carried by ctx when it has one of roles.
+// With no roles given any authenticated user is accepted.
+func requireRole(ctx context.Context, roles ...string) (*model.User, error) {
+	user, ok := helper.CurrentUser(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	if len(roles) == 0 {
+		return user, nil
+	}
+
+	for _, role := range roles {
+		if user.Role == role {
+			return user, nil
+		}
+	}
+
+	return nil, ErrForbidden
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reports/helper"
+	"reports/model"
+	"time"
+)
+
+// inTx runs fn in one transaction, so a report write and its revision are
+// stored together or not at all.
+func (r *ReportServiceImpl) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.reportRepository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	return helper.InTransaction(tx, fn)
+}
+
+// recordRevision appends a revision in tx describing how the report changed
+// from before to after. The snapshot is the report as it was just written
+// in tx; a deleted report, with a nil after, keeps before as its last
+// snapshot.
+func (r *ReportServiceImpl) recordRevision(ctx context.Context, tx *sql.Tx, action string, before, after *model.Report) error {
+	changes, err := model.DiffReports(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to record report history: %w", err)
+	}
+
+	snapshot := before
+	if after != nil {
+		written := *after
+		snapshot = &written
+	}
+
+	revision := model.ReportRevision{
+		ReportId:  snapshot.Id,
+		Action:    action,
+		Snapshot:  snapshot,
+		Changes:   changes,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if user, ok := helper.CurrentUser(ctx); ok {
+		revision.ActorId = user.Id
+		revision.ActorUsername = user.Username
+	}
+
+	if err := r.reportRevisionRepository.Save(ctx, tx, &revision); err != nil {
+		return fmt.Errorf("failed to record report history: %w", err)
+	}
+
+	return nil
+}
+
+// historyReport returns the report whose history is requested, falling back
+// to its last recorded snapshot once the report itself is gone.
+func (r *ReportServiceImpl) historyReport(ctx context.Context, reportId int) (*model.Report, error) {
+	report, err := r.reportRepository.FindById(ctx, reportId)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return report, err
+	}
+
+	revisions, err := r.reportRevisionRepository.FindByReportId(ctx, reportId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	latest, err := r.reportRevisionRepository.FindByRevision(ctx, reportId, revisions[len(revisions)-1].Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return latest.Snapshot, nil
+}
+
+func (r *ReportServiceImpl) History(ctx context.Context, reportId int) ([]*model.ReportRevision, error) {
+	report, err := r.historyReport(ctx, reportId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := authorizeReportFromContext(ctx, ActionReadReport, report); err != nil {
+		return nil, err
+	}
+
+	return r.reportRevisionRepository.FindByReportId(ctx, reportId)
+}
+
+func (r *ReportServiceImpl) FindRevision(ctx context.Context, reportId, revision int) (*model.ReportRevision, error) {
+	report, err := r.historyReport(ctx, reportId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := authorizeReportFromContext(ctx, ActionReadReport, report); err != nil {
+		return nil, err
+	}
+
+	return r.reportRevisionRepository.FindByRevision(ctx, reportId, revision)
+}
+
+// RestoreRevision brings the content of an earlier revision back into the
+// report. The workflow status is left as it is, so a restored report still
+// goes through review again.
+func (r *ReportServiceImpl) RestoreRevision(ctx context.Context, reportId, revision int) error {
+	existingReport, err := r.reportRepository.FindById(ctx, reportId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := authorizeReportFromContext(ctx, ActionUpdateReport, existingReport); err != nil {
+		return err
+	}
+
+	if existingReport.Status == model.ReportStatusApproved {
+		return ErrReportLocked
+	}
+
+	target, err := r.reportRevisionRepository.FindByRevision(ctx, reportId, revision)
+	if err != nil {
+		return err
+	}
+
+	before := *existingReport
+	copyReportContent(existingReport, target.Snapshot)
+
+	return r.update(ctx, model.RevisionActionRestore, existingReport, &before)
+}
+
+// copyReportContent copies everything a worker fills in from src to dst,
+// with the names that went with the ids, leaving the id, workflow and
+// bookkeeping fields alone.
+func copyReportContent(dst, src *model.Report) {
+	dst.MonthOf = src.MonthOf
+	dst.WorkerId = src.WorkerId
+	dst.WorkerName = src.WorkerName
+	dst.AreaId = src.AreaId
+	dst.AreaOfAssignment = src.AreaOfAssignment
+	dst.ChurchId = src.ChurchId
+	dst.NameOfChurch = src.NameOfChurch
+	dst.Activities = src.Activities
+	dst.Names = src.Names
+	dst.NarrativeReport = src.NarrativeReport
+	dst.ChallengesAndProblemEncountered = src.ChallengesAndProblemEncountered
+	dst.PrayerRequest = src.PrayerRequest
+}
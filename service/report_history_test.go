@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"reports/model"
+	"reports/repository"
+	"testing"
+)
+
+type stubRevisions struct {
+	repository.ReportRevisionRepository
+	saved []*model.ReportRevision
+}
+
+func (s *stubRevisions) Save(ctx context.Context, tx *sql.Tx, revision *model.ReportRevision) error {
+	s.saved = append(s.saved, revision)
+	return nil
+}
+
+func TestRecordRevisionSnapshotsWrittenReport(t *testing.T) {
+	revisions := &stubRevisions{}
+	// A nil report repository fails the test if the report is read again
+	service := &ReportServiceImpl{reportRevisionRepository: revisions}
+
+	before := &model.Report{Id: 5, NarrativeReport: "old"}
+	after := &model.Report{Id: 5, NarrativeReport: "new"}
+
+	if err := service.recordRevision(context.Background(), nil, model.RevisionActionUpdate, before, after); err != nil {
+		t.Fatalf("recordRevision() error = %v", err)
+	}
+	after.NarrativeReport = "changed later"
+
+	revision := revisions.saved[0]
+	if revision.ReportId != 5 || revision.Snapshot.NarrativeReport != "new" {
+		t.Fatalf("revision = %+v, want a snapshot of the written report", revision)
+	}
+	if len(revision.Changes) != 1 || revision.Changes[0].Field != "narrative_report" {
+		t.Fatalf("changes = %+v, want narrative_report", revision.Changes)
+	}
+
+	if err := service.recordRevision(context.Background(), nil, model.RevisionActionDelete, after, nil); err != nil {
+		t.Fatalf("recordRevision() error = %v", err)
+	}
+	if deleted := revisions.saved[1]; deleted.Snapshot != after || deleted.ReportId != 5 {
+		t.Fatalf("delete revision = %+v, want before as its snapshot", deleted)
+	}
+}
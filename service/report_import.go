@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"reports/data/request"
+	"reports/helper"
+	"reports/model"
+	"reports/utils"
+	"time"
+)
+
+// pendingImport is a report read from an upload together with the report it
+// replaces, if the worker already filed one for the month, as it was before.
+type pendingImport struct {
+	imported *model.ImportedReport
+	report   *model.Report
+	existing *model.Report
+	before   *model.Report
+}
+
+// ImportWorkbook creates or updates a report for every sheet of a workbook
+// laid out like the Excel export. All sheets are saved in one transaction:
+// nothing is saved unless every sheet is valid and stored. With dryRun
+// nothing is saved at all and the result only lists what would happen.
+func (r *ReportServiceImpl) ImportWorkbook(ctx context.Context, in io.Reader, dryRun bool) (*model.ReportImportResult, error) {
+	user, err := requireRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := r.activityRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sheets, err := utils.ParseReportWorkbook(in, catalog)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("%w: no sheet has a \"Month Of:\" label", ErrInvalidImport)
+	}
+
+	now := time.Now().In(model.Manila)
+
+	result := &model.ReportImportResult{DryRun: dryRun, Reports: []*model.ImportedReport{}, Errors: []model.ImportError{}}
+	pending := []pendingImport{}
+	seen := map[string]string{}
+
+	for _, sheet := range sheets {
+		report := sheet.Report
+		report.Status = model.ReportStatusDraft
+		report.CreatedAt = now
+		report.UpdatedAt = now
+
+		cells := map[string]string{"worker_name": sheet.WorkerCell, "area_of_assignment": sheet.AreaCell, "name_of_church": sheet.ChurchCell}
+		ref := importRef{WorkerName: sheet.WorkerName, AreaName: sheet.AreaName, ChurchName: sheet.ChurchName}
+		err := r.resolveImportIds(ctx, ref, &report, func(field, message string) {
+			sheet.Errors = append(sheet.Errors, model.ImportError{Sheet: sheet.Sheet, Cell: cells[field], Field: field, Message: message})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(sheet.Errors) == 0 {
+			create := request.ReportCreateRequest{
+				MonthOf:    report.MonthOf.String(),
+				WorkerId:   report.WorkerId,
+				AreaId:     report.AreaId,
+				ChurchId:   report.ChurchId,
+				Activities: report.Activities,
+			}
+			if err := create.Validate(); err != nil {
+				sheet.Errors = append(sheet.Errors, model.ImportError{Sheet: sheet.Sheet, Message: err.Error()})
+			}
+		}
+
+		imported := &model.ImportedReport{Sheet: sheet.Sheet, Action: model.ImportActionCreate, WorkerName: sheet.WorkerName, MonthOf: report.MonthOf}
+		var existing *model.Report
+
+		if len(sheet.Errors) == 0 {
+			key := fmt.Sprintf("%d/%s", report.WorkerId, report.MonthOf)
+			if other, ok := seen[key]; ok {
+				sheet.Errors = append(sheet.Errors, model.ImportError{Sheet: sheet.Sheet, Cell: sheet.MonthCell, Field: "month_of",
+					Message: fmt.Sprintf("sheet %q already has the report of %s for %s", other, sheet.WorkerName, report.MonthOf.Label())})
+			}
+			seen[key] = sheet.Sheet
+		}
+
+		if len(sheet.Errors) == 0 {
+			existing, err = r.reportRepository.ReportTaken(ctx, 0, report.MonthOf, report.WorkerId)
+			if err != nil {
+				return nil, err
+			}
+
+			action, target := ActionCreateReport, &report
+			if existing != nil {
+				action, target = ActionUpdateReport, existing
+				imported.Action = model.ImportActionUpdate
+				imported.ReportId = existing.Id
+			}
+
+			if err := authorizeReport(user, action, target); err != nil {
+				sheet.Errors = append(sheet.Errors, model.ImportError{Sheet: sheet.Sheet, Cell: sheet.WorkerCell, Field: "worker_name", Message: err.Error()})
+			} else if existing != nil && existing.Status == model.ReportStatusApproved {
+				sheet.Errors = append(sheet.Errors, model.ImportError{Sheet: sheet.Sheet, Cell: sheet.MonthCell, Field: "month_of",
+					Message: fmt.Sprintf("report %d is approved: %s", existing.Id, ErrReportLocked)})
+			}
+
+			var had model.ActivityValues
+			if existing != nil {
+				had = existing.Activities
+			}
+			if err := checkCatalog(catalog, report.Activities, had); err != nil {
+				sheet.Errors = append(sheet.Errors, model.ImportError{Sheet: sheet.Sheet, Field: "activities", Message: err.Error()})
+			}
+		}
+
+		result.Reports = append(result.Reports, imported)
+		result.Errors = append(result.Errors, sheet.Errors...)
+		pending = append(pending, pendingImport{imported: imported, report: &report, existing: existing})
+	}
+
+	result.Valid = len(result.Errors) == 0
+	if !result.Valid || dryRun {
+		return result, nil
+	}
+
+	// Check every sheet first, then save them all in one transaction
+	for i := range pending {
+		item := &pending[i]
+		var err error
+		if item.existing == nil {
+			err = r.checkCreate(ctx, item.report)
+		} else {
+			before := *item.existing
+			item.before = &before
+			copyReportContent(item.existing, item.report)
+			err = r.checkUpdate(ctx, model.RevisionActionUpdate, item.existing, item.before)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("sheet %q: %w", item.imported.Sheet, err)
+		}
+	}
+
+	err = r.inTx(ctx, func(tx *sql.Tx) error {
+		for _, item := range pending {
+			var err error
+			saved := item.report
+			if item.existing == nil {
+				err = r.insert(ctx, tx, item.report)
+			} else {
+				saved = item.existing
+				err = r.write(ctx, tx, model.RevisionActionUpdate, item.existing, item.before)
+			}
+			if helper.IsUniqueViolation(err) {
+				err = r.reportTakenError(ctx, saved.Id, saved.MonthOf, saved.WorkerId)
+			}
+			if err != nil {
+				return fmt.Errorf("sheet %q: %w", item.imported.Sheet, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range pending {
+		if item.existing == nil {
+			item.imported.ReportId = item.report.Id
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// DefaultImportBatchSize is the number of CSV lines imported per
+// transaction unless the caller asks otherwise.
+const DefaultImportBatchSize = 500
+
+// ImportCSV inserts a new report for every valid line of a CSV file with
+// the columns of the CSV export. Lines that fail validation, or whose worker
+// already filed a report for the month, are skipped and listed in the
+// result. The valid lines are inserted with their first revision batchSize
+// at a time, each batch in its own transaction; with batchSize 0 they all
+// share one transaction. A line the database turns down, because another
+// writer filed the same report or removed a worker meanwhile, is skipped
+// and listed without holding back the rest of its batch. Any other failure
+// stops the import: the batches before it stay saved, and the result is
+// returned along with the error to tell which lines went in.
+func (r *ReportServiceImpl) ImportCSV(ctx context.Context, in io.Reader, dryRun bool, batchSize int) (*model.ReportImportResult, error) {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return nil, err
+	}
+
+	catalog, err := r.activityRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := utils.ParseReportCSV(in, catalog)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: file has no reports", ErrInvalidImport)
+	}
+
+	now := time.Now().In(model.Manila)
+
+	result := &model.ReportImportResult{DryRun: dryRun, Reports: []*model.ImportedReport{}, Errors: []model.ImportError{}}
+	pending := []pendingImport{}
+	seen := map[string]int{}
+
+	for _, row := range rows {
+		report := row.Report
+		report.Status = model.ReportStatusDraft
+		report.CreatedAt = now
+		report.UpdatedAt = now
+
+		fail := func(field, message string) {
+			row.Errors = append(row.Errors, model.ImportError{Line: row.Line, Field: field, Message: message})
+		}
+
+		if len(row.Errors) == 0 {
+			ref := importRef{
+				WorkerId:   row.WorkerId,
+				WorkerName: row.WorkerName,
+				AreaId:     row.AreaId,
+				AreaName:   row.AreaName,
+				ChurchId:   row.ChurchId,
+				ChurchName: row.ChurchName,
+			}
+			if err := r.resolveImportIds(ctx, ref, &report, fail); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(row.Errors) == 0 {
+			create := request.ReportCreateRequest{
+				MonthOf:    row.MonthOf,
+				WorkerId:   report.WorkerId,
+				AreaId:     report.AreaId,
+				ChurchId:   report.ChurchId,
+				Activities: report.Activities,
+			}
+			if err := create.Validate(); err != nil {
+				fail("", err.Error())
+			}
+		}
+
+		if len(row.Errors) == 0 {
+			key := fmt.Sprintf("%d/%s", report.WorkerId, report.MonthOf)
+			if other, ok := seen[key]; ok {
+				fail("month_of", fmt.Sprintf("line %d already has the report of this worker for %s", other, report.MonthOf.Label()))
+			} else {
+				seen[key] = row.Line
+			}
+		}
+
+		if len(row.Errors) == 0 {
+			existing, err := r.reportRepository.ReportTaken(ctx, 0, report.MonthOf, report.WorkerId)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				fail("month_of", (&ReportConflictError{ExistingId: existing.Id}).Error())
+			}
+		}
+
+		if len(row.Errors) == 0 {
+			if err := checkCatalog(catalog, report.Activities, nil); err != nil {
+				fail("activities", err.Error())
+			}
+		}
+
+		imported := &model.ImportedReport{Line: row.Line, Action: model.ImportActionCreate, WorkerName: row.WorkerName, MonthOf: report.MonthOf}
+		if len(row.Errors) > 0 {
+			imported.Action = model.ImportActionSkip
+		} else {
+			pending = append(pending, pendingImport{imported: imported, report: &report})
+		}
+
+		result.Reports = append(result.Reports, imported)
+		result.Errors = append(result.Errors, row.Errors...)
+	}
+
+	result.Valid = len(result.Errors) == 0
+	if dryRun || len(pending) == 0 {
+		return result, nil
+	}
+
+	if batchSize <= 0 || batchSize > len(pending) {
+		batchSize = len(pending)
+	}
+
+	for start := 0; start < len(pending); start += batchSize {
+		batch := pending[start:min(start+batchSize, len(pending))]
+
+		// Each line gets a savepoint, so a line another writer got in ahead
+		// of is skipped on its own and the rest of the batch still goes in
+		saved := []pendingImport{}
+		failedLine := 0
+		err := r.inTx(ctx, func(tx *sql.Tx) error {
+			for _, item := range batch {
+				item.report.ComputeStats(r.averageRounding)
+
+				err := helper.InSavepoint(ctx, tx, func() error {
+					return r.insert(ctx, tx, item.report)
+				})
+				switch {
+				case helper.IsUniqueViolation(err):
+					err = r.reportTakenError(ctx, 0, item.report.MonthOf, item.report.WorkerId)
+					result.Errors = append(result.Errors, model.ImportError{Line: item.imported.Line, Field: "month_of", Message: err.Error()})
+				case helper.IsForeignKeyViolation(err):
+					result.Errors = append(result.Errors, model.ImportError{Line: item.imported.Line, Message: fmt.Sprintf("%s: %v", ErrReferenceNotFound, err)})
+				case err != nil:
+					failedLine = item.imported.Line
+					return fmt.Errorf("line %d: %w", item.imported.Line, err)
+				default:
+					saved = append(saved, item)
+					continue
+				}
+
+				item.report.Id = 0
+				item.imported.Action = model.ImportActionSkip
+				result.Valid = false
+			}
+			return nil
+		})
+		if err != nil {
+			// The earlier batches are committed; report them with the error
+			// so a rerun can skip what is already in
+			if failedLine == 0 {
+				failedLine = batch[0].imported.Line
+			}
+			for _, item := range pending[start:] {
+				item.report.Id = 0
+				item.imported.Action = model.ImportActionSkip
+			}
+			result.Errors = append(result.Errors, model.ImportError{Line: failedLine,
+				Message: fmt.Sprintf("import stopped, lines from %d on were not saved: %v", batch[0].imported.Line, err)})
+			result.Valid = false
+			return result, fmt.Errorf("failed to save reports: %w", err)
+		}
+
+		for _, item := range saved {
+			item.imported.ReportId = item.report.Id
+			result.Created++
+		}
+	}
+
+	return result, nil
+}
+
+// importRef names the worker, area and church of an imported report, by id
+// or by name. Ids win over names.
+type importRef struct {
+	WorkerId   int
+	WorkerName string
+	AreaId     int
+	AreaName   string
+	ChurchId   int
+	ChurchName string
+}
+
+// resolveImportIds looks up the worker, area and church of ref and stores
+// their ids on report. A missing area or church falls back to the worker's
+// assignment. References that match nothing are passed to fail with the
+// column they came from.
+func (r *ReportServiceImpl) resolveImportIds(ctx context.Context, ref importRef, report *model.Report, fail func(field, message string)) error {
+	var worker *model.Worker
+	var err error
+
+	switch {
+	case ref.WorkerId > 0:
+		worker, err = r.workerRepository.FindById(ctx, ref.WorkerId)
+		if errors.Is(err, sql.ErrNoRows) {
+			fail("worker_id", fmt.Sprintf("worker %d not found", ref.WorkerId))
+			return nil
+		}
+	case ref.WorkerName != "":
+		worker, err = r.workerRepository.FindByName(ctx, ref.WorkerName)
+		if errors.Is(err, sql.ErrNoRows) {
+			fail("worker_name", fmt.Sprintf("worker %q not found", ref.WorkerName))
+			return nil
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	report.WorkerId = worker.Id
+	report.WorkerName = worker.Name
+
+	switch {
+	case ref.AreaId > 0:
+		area, err := r.areaRepository.FindById(ctx, ref.AreaId)
+		if errors.Is(err, sql.ErrNoRows) {
+			fail("area_id", fmt.Sprintf("area %d not found", ref.AreaId))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		report.AreaId = ref.AreaId
+		report.AreaOfAssignment = area.Name
+	case ref.AreaName != "":
+		area, err := r.areaRepository.FindByName(ctx, ref.AreaName)
+		if errors.Is(err, sql.ErrNoRows) {
+			fail("area_of_assignment", fmt.Sprintf("area %q not found", ref.AreaName))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		report.AreaId = area.Id
+		report.AreaOfAssignment = area.Name
+	default:
+		report.AreaId = worker.AreaId
+		report.AreaOfAssignment = worker.AreaName
+	}
+
+	if worker.AreaId > 0 && report.AreaId != worker.AreaId {
+		field := "area_id"
+		if ref.AreaId <= 0 {
+			field = "area_of_assignment"
+		}
+		fail(field, ErrAreaNotAssigned.Error())
+		return nil
+	}
+
+	switch {
+	case ref.ChurchId > 0:
+		church, err := r.churchRepository.FindById(ctx, ref.ChurchId)
+		if errors.Is(err, sql.ErrNoRows) {
+			fail("church_id", fmt.Sprintf("church %d not found", ref.ChurchId))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if church.AreaId != report.AreaId {
+			fail("church_id", ErrChurchNotInArea.Error())
+			return nil
+		}
+		report.ChurchId = church.Id
+		report.NameOfChurch = church.Name
+	case ref.ChurchName != "":
+		church, err := r.churchRepository.FindByName(ctx, report.AreaId, ref.ChurchName)
+		if errors.Is(err, sql.ErrNoRows) {
+			fail("name_of_church", fmt.Sprintf("church %q not found in the area of assignment", ref.ChurchName))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		report.ChurchId = church.Id
+		report.NameOfChurch = church.Name
+	case report.AreaId == worker.AreaId:
+		report.ChurchId = worker.ChurchId
+		report.NameOfChurch = worker.ChurchName
+	}
+
+	return nil
+}
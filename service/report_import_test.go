@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reports/helper"
+	"reports/model"
+	"reports/repository"
+	"reports/utils"
+	"strings"
+	"testing"
+)
+
+type stubActivities struct {
+	repository.ActivityRepository
+	catalog model.ActivityCatalog
+}
+
+func (s stubActivities) FindAll(ctx context.Context) (model.ActivityCatalog, error) {
+	return s.catalog, nil
+}
+
+// importReports holds one stored report and records the updates of it.
+type importReports struct {
+	repository.ReportRepository
+	db      txDB
+	stored  *model.Report
+	updated []*model.Report
+}
+
+func (s *importReports) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return s.db.BeginTx(ctx)
+}
+
+func (s *importReports) ReportTaken(ctx context.Context, id int, monthOf model.Period, workerId int) (*model.Report, error) {
+	if s.stored.Id != id && s.stored.MonthOf == monthOf && s.stored.WorkerId == workerId {
+		stored := *s.stored
+		return &stored, nil
+	}
+	return nil, nil
+}
+
+func (s *importReports) Update(ctx context.Context, tx *sql.Tx, report *model.Report) error {
+	updated := *report
+	s.updated = append(s.updated, &updated)
+	return nil
+}
+
+func TestImportWorkbookRecordsUpdateChanges(t *testing.T) {
+	catalog := model.ActivityCatalog{{Id: 1, Key: "worship_service", Label: "Worship Service", Category: model.ActivityCategoryAttendance, Active: true}}
+	monthOf, _ := model.ParsePeriod("2024-03")
+
+	stored := &model.Report{
+		Id:               5,
+		MonthOf:          monthOf,
+		Status:           model.ReportStatusDraft,
+		WorkerId:         10,
+		WorkerName:       "Juan Dela Cruz",
+		AreaId:           1,
+		AreaOfAssignment: "Luzon",
+		ChurchId:         100,
+		NameOfChurch:     "Grace Church",
+		Activities:       model.ActivityValues{"worship_service": {40, 42}},
+		NarrativeReport:  "old narrative",
+	}
+
+	uploaded := *stored
+	uploaded.NarrativeReport = "new narrative"
+
+	workbook, err := utils.NewReportWorkbook(catalog)
+	if err != nil {
+		t.Fatalf("NewReportWorkbook() error = %v", err)
+	}
+	defer workbook.Close()
+	if err := workbook.AddReport(&uploaded); err != nil {
+		t.Fatalf("AddReport() error = %v", err)
+	}
+	var file bytes.Buffer
+	if _, err := workbook.WriteTo(&file); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+
+	reports := &importReports{db: newTxDB(), stored: stored}
+	revisions := &stubRevisions{}
+	service := &ReportServiceImpl{
+		reportRepository:         reports,
+		reportRevisionRepository: revisions,
+		activityRepository:       stubActivities{catalog: catalog},
+		workerRepository:         stubWorkers{workers: map[int]*model.Worker{10: {Id: 10, Name: "Juan Dela Cruz", AreaId: 1, AreaName: "Luzon", ChurchId: 100, ChurchName: "Grace Church"}}},
+		areaRepository:           stubAreas{areas: map[int]*model.Area{1: {Id: 1, Name: "Luzon"}}},
+		churchRepository:         stubChurches{churches: map[int]*model.Church{100: {Id: 100, AreaId: 1, Name: "Grace Church"}}},
+	}
+
+	ctx := helper.WithCurrentUser(context.Background(), &model.User{Id: 1, Role: model.RoleNationalAdmin})
+	result, err := service.ImportWorkbook(ctx, &file, false)
+	if err != nil {
+		t.Fatalf("ImportWorkbook() error = %v", err)
+	}
+	if result.Updated != 1 || len(reports.updated) != 1 {
+		t.Fatalf("result = %+v with %d updates, want the stored report updated", result, len(reports.updated))
+	}
+
+	if len(revisions.saved) != 1 {
+		t.Fatalf("%d revisions recorded, want 1", len(revisions.saved))
+	}
+	revision := revisions.saved[0]
+	if revision.Action != model.RevisionActionUpdate || len(revision.Changes) != 1 {
+		t.Fatalf("revision = %s with changes %+v, want only the narrative changed", revision.Action, revision.Changes)
+	}
+
+	change := revision.Changes[0]
+	var old, new string
+	json.Unmarshal(change.Old, &old)
+	json.Unmarshal(change.New, &new)
+	if change.Field != "narrative_report" || old != "old narrative" || new != "new narrative" {
+		t.Fatalf("change = %s from %s to %s, want narrative_report from the stored text", change.Field, change.Old, change.New)
+	}
+}
+
+// csvReports saves reports in memory and fails the save of failMonth.
+type csvReports struct {
+	repository.ReportRepository
+	db        txDB
+	failMonth model.Period
+	saved     []*model.Report
+}
+
+func (s *csvReports) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return s.db.BeginTx(ctx)
+}
+
+func (s *csvReports) ReportTaken(ctx context.Context, id int, monthOf model.Period, workerId int) (*model.Report, error) {
+	return nil, nil
+}
+
+func (s *csvReports) Save(ctx context.Context, tx *sql.Tx, report *model.Report) error {
+	if report.MonthOf == s.failMonth {
+		return errors.New("disk full")
+	}
+	report.Id = len(s.saved) + 1
+	s.saved = append(s.saved, report)
+	return nil
+}
+
+func TestImportCSVReturnsSavedBatchesOnFailure(t *testing.T) {
+	catalog := model.ActivityCatalog{{Id: 1, Key: "worship_service", Label: "Worship Service", Category: model.ActivityCategoryAttendance, Active: true}}
+	failMonth, _ := model.ParsePeriod("2024-03")
+
+	reports := &csvReports{db: newTxDB(), failMonth: failMonth}
+	service := &ReportServiceImpl{
+		reportRepository:         reports,
+		reportRevisionRepository: &stubRevisions{},
+		activityRepository:       stubActivities{catalog: catalog},
+		workerRepository:         stubWorkers{workers: map[int]*model.Worker{10: {Id: 10, Name: "Juan Dela Cruz", AreaId: 1, AreaName: "Luzon", ChurchId: 100, ChurchName: "Grace Church"}}},
+	}
+
+	input := "month_of,worker_name,worship_service_w1\n" +
+		"2024-01,Juan Dela Cruz,10\n" +
+		"2024-02,Juan Dela Cruz,11\n" +
+		"2024-03,Juan Dela Cruz,12\n" +
+		"2024-04,Juan Dela Cruz,13\n"
+
+	ctx := helper.WithCurrentUser(context.Background(), &model.User{Id: 1, Role: model.RoleNationalAdmin})
+	result, err := service.ImportCSV(ctx, strings.NewReader(input), false, 2)
+	if err == nil {
+		t.Fatalf("ImportCSV() error = nil, want the failed save")
+	}
+	if result == nil {
+		t.Fatalf("ImportCSV() result = nil, want the lines saved before the failure")
+	}
+
+	if result.Created != 2 || result.Valid {
+		t.Errorf("result created %d, valid %v, want the first batch of 2 and not valid", result.Created, result.Valid)
+	}
+	for i, imported := range result.Reports {
+		saved := i < 2
+		if (imported.ReportId != 0) != saved || (imported.Action == model.ImportActionCreate) != saved {
+			t.Errorf("line %d: id %d, action %s, saved %v", imported.Line, imported.ReportId, imported.Action, saved)
+		}
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Line != 4 {
+		t.Errorf("errors = %+v, want one pinned to line 4", result.Errors)
+	}
+}
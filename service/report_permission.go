@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"reports/helper"
+	"reports/model"
+)
+
+type ReportAction string
+
+const (
+	ActionReadReport   ReportAction = "read"
+	ActionCreateReport ReportAction = "create"
+	ActionUpdateReport ReportAction = "update"
+	ActionDeleteReport ReportAction = "delete"
+	ActionReviewReport ReportAction = "review"
+)
+
+// authorizeReport decides whether user may perform action on report.
+//
+// National admins may do anything. Workers may read, create and edit only
+// the reports filed under their own worker record. Area supervisors
+// additionally read every report in their area of assignment and review
+// (approve or return) the ones they did not write themselves. Only national
+// admins may delete.
+func authorizeReport(user *model.User, action ReportAction, report *model.Report) error {
+	if user == nil {
+		return ErrUnauthenticated
+	}
+
+	if user.Role == model.RoleNationalAdmin {
+		return nil
+	}
+
+	isOwner := user.WorkerId > 0 && user.WorkerId == report.WorkerId
+
+	switch action {
+	case ActionReadReport:
+		if isOwner {
+			return nil
+		}
+		if user.Role == model.RoleAreaSupervisor && user.AreaId > 0 && user.AreaId == report.AreaId {
+			return nil
+		}
+	case ActionCreateReport, ActionUpdateReport:
+		if isOwner {
+			return nil
+		}
+	case ActionReviewReport:
+		if user.Role == model.RoleAreaSupervisor && !isOwner && user.AreaId > 0 && user.AreaId == report.AreaId {
+			return nil
+		}
+	}
+
+	return ErrForbidden
+}
+
+// authorizeReportFromContext runs authorizeReport for the user carried by ctx.
+func authorizeReportFromContext(ctx context.Context, action ReportAction, report *model.Report) (*model.User, error) {
+	user, ok := helper.CurrentUser(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	if err := authorizeReport(user, action, report); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// scopeReportQuery narrows a list query to the reports user may read.
+func scopeReportQuery(user *model.User, query *model.SearchReportQuery) error {
+	switch user.Role {
+	case model.RoleNationalAdmin:
+		return nil
+	case model.RoleAreaSupervisor:
+		if user.AreaId <= 0 {
+			return ErrForbidden
+		}
+		query.ScopeAreaId = user.AreaId
+		return nil
+	case model.RoleWorker:
+		if user.WorkerId <= 0 {
+			return ErrForbidden
+		}
+		query.ScopeWorkerId = user.WorkerId
+		return nil
+	}
+
+	return ErrForbidden
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"reports/model"
+	"testing"
+)
+
+func TestAuthorizeReport(t *testing.T) {
+	const luzon, mindanao = 1, 2
+
+	worker := &model.User{Id: 1, Role: model.RoleWorker, WorkerId: 10, AreaId: luzon}
+	supervisor := &model.User{Id: 2, Role: model.RoleAreaSupervisor, WorkerId: 20, AreaId: luzon}
+	admin := &model.User{Id: 3, Role: model.RoleNationalAdmin}
+
+	own := &model.Report{WorkerId: 10, AreaId: luzon}
+	sameArea := &model.Report{WorkerId: 30, AreaId: luzon}
+	otherArea := &model.Report{WorkerId: 40, AreaId: mindanao}
+	supervisorOwn := &model.Report{WorkerId: 20, AreaId: luzon}
+
+	tests := []struct {
+		name   string
+		user   *model.User
+		action ReportAction
+		report *model.Report
+		want   error
+	}{
+		{"worker reads own", worker, ActionReadReport, own, nil},
+		{"worker reads same area", worker, ActionReadReport, sameArea, ErrForbidden},
+		{"worker reads other area", worker, ActionReadReport, otherArea, ErrForbidden},
+		{"worker creates own", worker, ActionCreateReport, own, nil},
+		{"worker creates for someone else", worker, ActionCreateReport, sameArea, ErrForbidden},
+		{"worker updates own", worker, ActionUpdateReport, own, nil},
+		{"worker updates someone else", worker, ActionUpdateReport, sameArea, ErrForbidden},
+		{"worker deletes own", worker, ActionDeleteReport, own, ErrForbidden},
+		{"worker reviews own", worker, ActionReviewReport, own, ErrForbidden},
+		{"worker reviews same area", worker, ActionReviewReport, sameArea, ErrForbidden},
+
+		{"supervisor reads own", supervisor, ActionReadReport, supervisorOwn, nil},
+		{"supervisor reads same area", supervisor, ActionReadReport, sameArea, nil},
+		{"supervisor reads other area", supervisor, ActionReadReport, otherArea, ErrForbidden},
+		{"supervisor creates own", supervisor, ActionCreateReport, supervisorOwn, nil},
+		{"supervisor creates for area worker", supervisor, ActionCreateReport, sameArea, ErrForbidden},
+		{"supervisor updates own", supervisor, ActionUpdateReport, supervisorOwn, nil},
+		{"supervisor updates area worker", supervisor, ActionUpdateReport, sameArea, ErrForbidden},
+		{"supervisor deletes area worker", supervisor, ActionDeleteReport, sameArea, ErrForbidden},
+		{"supervisor reviews area worker", supervisor, ActionReviewReport, sameArea, nil},
+		{"supervisor reviews own", supervisor, ActionReviewReport, supervisorOwn, ErrForbidden},
+		{"supervisor reviews other area", supervisor, ActionReviewReport, otherArea, ErrForbidden},
+
+		{"admin reads any", admin, ActionReadReport, otherArea, nil},
+		{"admin creates any", admin, ActionCreateReport, otherArea, nil},
+		{"admin updates any", admin, ActionUpdateReport, otherArea, nil},
+		{"admin deletes any", admin, ActionDeleteReport, otherArea, nil},
+		{"admin reviews any", admin, ActionReviewReport, otherArea, nil},
+
+		{"anonymous reads", nil, ActionReadReport, own, ErrUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorizeReport(tt.user, tt.action, tt.report)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("authorizeReport() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeReportQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		user      *model.User
+		wantWork  int
+		wantArea  int
+		wantError error
+	}{
+		{"worker", &model.User{Role: model.RoleWorker, WorkerId: 10}, 10, 0, nil},
+		{"supervisor", &model.User{Role: model.RoleAreaSupervisor, AreaId: 1}, 0, 1, nil},
+		{"admin", &model.User{Role: model.RoleNationalAdmin}, 0, 0, nil},
+		{"worker without worker record", &model.User{Role: model.RoleWorker}, 0, 0, ErrForbidden},
+		{"unknown role", &model.User{Role: "guest"}, 0, 0, ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := &model.SearchReportQuery{}
+			err := scopeReportQuery(tt.user, query)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("scopeReportQuery() = %v, want %v", err, tt.wantError)
+			}
+			if query.ScopeWorkerId != tt.wantWork || query.ScopeAreaId != tt.wantArea {
+				t.Fatalf("scope = (%d, %d), want (%d, %d)", query.ScopeWorkerId, query.ScopeAreaId, tt.wantWork, tt.wantArea)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"reports/data/request"
 	"reports/model"
 )
@@ -12,4 +13,17 @@ type ReportService interface {
 	Delete(ctx context.Context, reportId int) error
 	FindById(ctx context.Context, reportId int) (*model.Report, error)
 	FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error)
+	StreamReports(ctx context.Context, query *model.SearchReportQuery, fn func(report *model.Report) error) error
+	Trash(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error)
+	Restore(ctx context.Context, reportId int) error
+	Submit(ctx context.Context, reportId int) error
+	Approve(ctx context.Context, request *request.ReportApproveRequest) error
+	Return(ctx context.Context, request *request.ReportReturnRequest) error
+	History(ctx context.Context, reportId int) ([]*model.ReportRevision, error)
+	FindRevision(ctx context.Context, reportId, revision int) (*model.ReportRevision, error)
+	RestoreRevision(ctx context.Context, reportId, revision int) error
+	ImportWorkbook(ctx context.Context, in io.Reader, dryRun bool) (*model.ReportImportResult, error)
+	Template(ctx context.Context, workerId int, month string) (*model.Report, error)
+	ImportCSV(ctx context.Context, in io.Reader, dryRun bool, batchSize int) (*model.ReportImportResult, error)
+	RecomputeStats(ctx context.Context) (int, error)
 }
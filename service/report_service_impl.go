@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"reports/config"
 	"reports/data/request"
+	"reports/helper"
 	"reports/model"
 	"reports/repository"
 	"time"
@@ -13,72 +16,98 @@ import (
 )
 
 type ReportServiceImpl struct {
-	reportRepository repository.ReportRepository
-	paginationConfig config.PaginationConfig
+	reportRepository         repository.ReportRepository
+	reportRevisionRepository repository.ReportRevisionRepository
+	workerRepository         repository.WorkerRepository
+	areaRepository           repository.AreaRepository
+	churchRepository         repository.ChurchRepository
+	activityRepository       repository.ActivityRepository
+	paginationConfig         config.PaginationConfig
+	averageRounding          model.AverageRounding
 }
 
-func NewReportServiceImpl(reportRepository repository.ReportRepository) ReportService {
-	return &ReportServiceImpl{reportRepository: reportRepository}
+func NewReportServiceImpl(reportRepository repository.ReportRepository, reportRevisionRepository repository.ReportRevisionRepository,
+	workerRepository repository.WorkerRepository, areaRepository repository.AreaRepository, churchRepository repository.ChurchRepository,
+	activityRepository repository.ActivityRepository, averageRounding model.AverageRounding) ReportService {
+	return &ReportServiceImpl{
+		reportRepository:         reportRepository,
+		reportRevisionRepository: reportRevisionRepository,
+		workerRepository:         workerRepository,
+		areaRepository:           areaRepository,
+		churchRepository:         churchRepository,
+		activityRepository:       activityRepository,
+		averageRounding:          averageRounding,
+	}
 }
 
 func (r *ReportServiceImpl) Create(ctx context.Context, request *request.ReportCreateRequest) error {
-	// result, err := r.reportRepository.ReportTaken(ctx, 0, request.MonthOf, request.WorkerName)
-	// if err != nil {
-	// 	return err
-	// }
-
-	// if result != nil {
-	// 	return errors.New("report already taken")
-	// }
-
-	loc, err := time.LoadLocation("Asia/Manila")
+	monthOf, err := model.ParsePeriod(request.MonthOf)
 	if err != nil {
 		return err
 	}
 
-	now := time.Now().In(loc)
+	now := time.Now().In(model.Manila)
 
 	report := model.Report{
-		MonthOf:                         request.MonthOf,
-		WorkerName:                      request.WorkerName,
-		AreaOfAssignment:                request.AreaOfAssignment,
-		NameOfChurch:                    request.NameOfChurch,
-		WorshipService:                  request.WorshipService,
-		SundaySchool:                    request.SundaySchool,
-		PrayerMeetings:                  request.PrayerMeetings,
-		BibleStudies:                    request.BibleStudies,
-		MensFellowships:                 request.MensFellowships,
-		WomensFellowships:               request.WomensFellowships,
-		YouthFellowships:                request.YouthFellowships,
-		ChildFellowships:                request.ChildFellowships,
-		Outreach:                        request.Outreach,
-		TrainingOrSeminars:              request.TrainingOrSeminars,
-		LeadershipConferences:           request.LeadershipConferences,
-		LeadershipTraining:              request.LeadershipTraining,
-		Others:                          request.Others,
-		FamilyDays:                      request.FamilyDays,
-		TithesAndOfferings:              request.TithesAndOfferings,
-		HomeVisited:                     request.HomeVisited,
-		BibleStudyOrGroupLed:            request.BibleStudyOrGroupLed,
-		SermonOrMessagePreached:         request.SermonOrMessagePreached,
-		PersonNewlyContacted:            request.PersonNewlyContacted,
-		PersonFollowedUp:                request.PersonFollowedUp,
-		PersonLedToChrist:               request.PersonLedToChrist,
+		MonthOf:                         monthOf,
+		WorkerId:                        request.WorkerId,
+		AreaId:                          request.AreaId,
+		ChurchId:                        request.ChurchId,
+		Activities:                      request.Activities,
 		Names:                           request.Names,
 		NarrativeReport:                 request.NarrativeReport,
 		ChallengesAndProblemEncountered: request.ChallengesAndProblemEncountered,
 		PrayerRequest:                   request.PrayerRequest,
+		Status:                          model.ReportStatusDraft,
 		CreatedAt:                       now,
 		UpdatedAt:                       now,
 	}
 
-	// Save the report using the repository
-	err = r.reportRepository.Save(ctx, &report)
-	if err != nil {
-		return fmt.Errorf("failed to save report: %w", err)
+	return r.create(ctx, &report)
+}
+
+// create saves a new report on behalf of the user in ctx and records its
+// first revision.
+func (r *ReportServiceImpl) create(ctx context.Context, report *model.Report) error {
+	if err := r.checkCreate(ctx, report); err != nil {
+		return err
 	}
 
-	return nil
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		return r.insert(ctx, tx, report)
+	})
+	if helper.IsUniqueViolation(err) {
+		return r.reportTakenError(ctx, 0, report.MonthOf, report.WorkerId)
+	}
+	return err
+}
+
+// checkCreate runs the checks of create and computes the stats of report,
+// leaving the write to insert.
+func (r *ReportServiceImpl) checkCreate(ctx context.Context, report *model.Report) error {
+	if _, err := authorizeReportFromContext(ctx, ActionCreateReport, report); err != nil {
+		return err
+	}
+
+	if err := r.checkAssignment(ctx, report, nil); err != nil {
+		return err
+	}
+
+	if err := r.checkActivities(ctx, report, nil); err != nil {
+		return err
+	}
+
+	report.ComputeStats(r.averageRounding)
+
+	return r.checkReportTaken(ctx, 0, report.MonthOf, report.WorkerId)
+}
+
+// insert saves a checked report and its first revision in tx.
+func (r *ReportServiceImpl) insert(ctx context.Context, tx *sql.Tx, report *model.Report) error {
+	if err := r.reportRepository.Save(ctx, tx, report); err != nil {
+		return fmt.Errorf("failed to save report: %w", err)
+	}
+	return r.recordRevision(ctx, tx, model.RevisionActionCreate, nil, report)
 }
 
 func (r *ReportServiceImpl) Delete(ctx context.Context, reportId int) error {
@@ -88,16 +117,30 @@ func (r *ReportServiceImpl) Delete(ctx context.Context, reportId int) error {
 		return err // Return error if FindById fails
 	}
 
-	// Delete the report using its ID
-	err = r.reportRepository.Delete(ctx, report.Id)
+	user, err := authorizeReportFromContext(ctx, ActionDeleteReport, report)
 	if err != nil {
-		return err // Return error if Delete fails
+		return err
 	}
 
-	return nil
+	// Move the report to the trash; it can be restored until it is purged
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if err := r.reportRepository.Delete(ctx, tx, report.Id, user.Id, time.Now().UTC()); err != nil {
+			return err
+		}
+		return r.recordRevision(ctx, tx, model.RevisionActionDelete, report, nil)
+	})
 }
 
 func (r *ReportServiceImpl) FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error) {
+	user, ok := helper.CurrentUser(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	if err := scopeReportQuery(user, query); err != nil {
+		return nil, err
+	}
+
 	// Initialize pagination parameters if they are not provided or invalid
 	if query.Page <= 0 {
 		query.Page = r.paginationConfig.Page
@@ -121,83 +164,42 @@ func (r *ReportServiceImpl) FindById(ctx context.Context, id int) (*model.Report
 		return nil, err // Return error if FindById fails
 	}
 
+	if _, err := authorizeReportFromContext(ctx, ActionReadReport, report); err != nil {
+		return nil, err
+	}
+
 	reportResp := &model.Report{
 		Id:                              report.Id,
 		MonthOf:                         report.MonthOf,
+		WorkerId:                        report.WorkerId,
 		WorkerName:                      report.WorkerName,
+		AreaId:                          report.AreaId,
 		AreaOfAssignment:                report.AreaOfAssignment,
+		ChurchId:                        report.ChurchId,
 		NameOfChurch:                    report.NameOfChurch,
-		WorshipService:                  report.WorshipService,
-		SundaySchool:                    report.SundaySchool,
-		PrayerMeetings:                  report.PrayerMeetings,
-		BibleStudies:                    report.BibleStudies,
-		MensFellowships:                 report.MensFellowships,
-		WomensFellowships:               report.WomensFellowships,
-		YouthFellowships:                report.YouthFellowships,
-		ChildFellowships:                report.ChildFellowships,
-		Outreach:                        report.Outreach,
-		TrainingOrSeminars:              report.TrainingOrSeminars,
-		LeadershipConferences:           report.LeadershipConferences,
-		LeadershipTraining:              report.LeadershipTraining,
-		Others:                          report.Others,
-		FamilyDays:                      report.FamilyDays,
-		TithesAndOfferings:              report.TithesAndOfferings,
-		HomeVisited:                     report.HomeVisited,
-		BibleStudyOrGroupLed:            report.BibleStudyOrGroupLed,
-		SermonOrMessagePreached:         report.SermonOrMessagePreached,
-		PersonNewlyContacted:            report.PersonNewlyContacted,
-		PersonFollowedUp:                report.PersonFollowedUp,
-		PersonLedToChrist:               report.PersonLedToChrist,
+		Activities:                      report.Activities,
 		Names:                           report.Names,
 		NarrativeReport:                 report.NarrativeReport,
 		ChallengesAndProblemEncountered: report.ChallengesAndProblemEncountered,
 		PrayerRequest:                   report.PrayerRequest,
+		Status:                          report.Status,
+		ReviewComment:                   report.ReviewComment,
+		SubmittedAt:                     report.SubmittedAt,
+		ReviewedAt:                      report.ReviewedAt,
+		ReviewedBy:                      report.ReviewedBy,
 		CreatedAt:                       report.CreatedAt,
 		UpdatedAt:                       report.UpdatedAt,
+		Stats:                           report.Stats,
 	}
 
-	// Calculate average attendance for each type
-	reportResp.WorshipServiceAvg = model.CalculateAverage(report.WorshipService)
-	reportResp.SundaySchoolAvg = model.CalculateAverage(report.SundaySchool)
-	reportResp.PrayerMeetingsAvg = model.CalculateAverage(report.PrayerMeetings)
-	reportResp.BibleStudiesAvg = model.CalculateAverage(report.BibleStudies)
-	reportResp.MensFellowshipsAvg = model.CalculateAverage(report.MensFellowships)
-	reportResp.WomensFellowshipsAvg = model.CalculateAverage(report.WomensFellowships)
-	reportResp.YouthFellowshipsAvg = model.CalculateAverage(report.YouthFellowships)
-	reportResp.ChildFellowshipsAvg = model.CalculateAverage(report.ChildFellowships)
-	reportResp.OutreachAvg = model.CalculateAverage(report.Outreach)
-	reportResp.TrainingOrSeminarsAvg = model.CalculateAverage(report.TrainingOrSeminars)
-	reportResp.LeadershipConferencesAvg = model.CalculateAverage(report.LeadershipConferences)
-	reportResp.LeadershipTrainingAvg = model.CalculateAverage(report.LeadershipTraining)
-	reportResp.OthersAvg = model.CalculateAverage(report.Others)
-	reportResp.FamilyDaysAvg = model.CalculateAverage(report.FamilyDays)
-	reportResp.TithesAndOfferingsAvg = model.CalculateAverage(report.TithesAndOfferings)
-	reportResp.HomeVisitedAvg = model.CalculateAverage(report.HomeVisited)
-	reportResp.BibleStudyOrGroupLedAvg = model.CalculateAverage(report.BibleStudyOrGroupLed)
-	reportResp.SermonOrMessagePreachedAvg = model.CalculateAverage(report.SermonOrMessagePreached)
-	reportResp.PersonNewlyContactedAvg = model.CalculateAverage(report.PersonNewlyContacted)
-	reportResp.PersonFollowedUpAvg = model.CalculateAverage(report.PersonFollowedUp)
-	reportResp.PersonLedToChristAvg = model.CalculateAverage(report.PersonLedToChrist)
-
 	return reportResp, nil
 }
 
 func (r *ReportServiceImpl) Update(ctx context.Context, request *request.ReportUpdateRequest) error {
-	// result, err := r.reportRepository.ReportTaken(ctx, request.Id, request.MonthOf, request.WorkerName)
-	// if err != nil {
-	// 	return err
-	// }
-
-	// // Check if the report exists
-
-	// if len(result) == 0 {
-	// 	return errors.New("report already taken")
-	// }
-
-	// // Check if there is any report with the same month and worker name that is not the current report
-	// if len(result) > 1 || (len(result) == 1 && result[0].Id != request.Id) {
-	// 	return errors.New("report already taken")
-	// }
+	monthOf, err := model.ParsePeriod(request.MonthOf)
+	if err != nil {
+		return err
+	}
 
 	// Retrieve the existing report by ID
 	existingReport, err := r.reportRepository.FindById(ctx, request.Id)
@@ -205,43 +207,83 @@ func (r *ReportServiceImpl) Update(ctx context.Context, request *request.ReportU
 		return err
 	}
 
+	if _, err := authorizeReportFromContext(ctx, ActionUpdateReport, existingReport); err != nil {
+		return err
+	}
+
+	if existingReport.Status == model.ReportStatusApproved {
+		return ErrReportLocked
+	}
+
+	before := *existingReport
+
 	// Update the fields of the existing report entity with request data
-	existingReport.MonthOf = request.MonthOf
-	existingReport.WorkerName = request.WorkerName
-	existingReport.AreaOfAssignment = request.AreaOfAssignment
-	existingReport.NameOfChurch = request.NameOfChurch
-	existingReport.WorshipService = request.WorshipService
-	existingReport.SundaySchool = request.SundaySchool
-	existingReport.PrayerMeetings = request.PrayerMeetings
-	existingReport.BibleStudies = request.BibleStudies
-	existingReport.MensFellowships = request.MensFellowships
-	existingReport.WomensFellowships = request.WomensFellowships
-	existingReport.YouthFellowships = request.YouthFellowships
-	existingReport.ChildFellowships = request.ChildFellowships
-	existingReport.Outreach = request.Outreach
-	existingReport.TrainingOrSeminars = request.TrainingOrSeminars
-	existingReport.LeadershipConferences = request.LeadershipConferences
-	existingReport.LeadershipTraining = request.LeadershipTraining
-	existingReport.Others = request.Others
-	existingReport.FamilyDays = request.FamilyDays
-	existingReport.TithesAndOfferings = request.TithesAndOfferings
-	existingReport.HomeVisited = request.HomeVisited
-	existingReport.BibleStudyOrGroupLed = request.BibleStudyOrGroupLed
-	existingReport.SermonOrMessagePreached = request.SermonOrMessagePreached
-	existingReport.PersonNewlyContacted = request.PersonNewlyContacted
-	existingReport.PersonFollowedUp = request.PersonFollowedUp
-	existingReport.PersonLedToChrist = request.PersonLedToChrist
+	existingReport.MonthOf = monthOf
+	existingReport.WorkerId = request.WorkerId
+	existingReport.AreaId = request.AreaId
+	existingReport.ChurchId = request.ChurchId
+	existingReport.Activities = request.Activities
 	existingReport.Names = request.Names
 	existingReport.NarrativeReport = request.NarrativeReport
 	existingReport.ChallengesAndProblemEncountered = request.ChallengesAndProblemEncountered
 	existingReport.PrayerRequest = request.PrayerRequest
 
-	err = r.reportRepository.Update(ctx, existingReport)
-	if err != nil {
+	return r.update(ctx, model.RevisionActionUpdate, existingReport, &before)
+}
+
+// update saves the edited report and records the change from before under
+// action. The caller has already checked that the user may edit before.
+func (r *ReportServiceImpl) update(ctx context.Context, action string, report, before *model.Report) error {
+	if err := r.checkUpdate(ctx, action, report, before); err != nil {
 		return err
 	}
 
-	return nil
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		return r.write(ctx, tx, action, report, before)
+	})
+	if helper.IsUniqueViolation(err) {
+		return r.reportTakenError(ctx, report.Id, report.MonthOf, report.WorkerId)
+	}
+	return err
+}
+
+// checkUpdate runs the checks of update and computes the stats of report,
+// leaving the write to write.
+func (r *ReportServiceImpl) checkUpdate(ctx context.Context, action string, report, before *model.Report) error {
+	report.UpdatedAt = time.Now().UTC()
+
+	// Restoring an old revision may bring back activities retired since
+	if action != model.RevisionActionRestore {
+		if err := r.checkActivities(ctx, report, before); err != nil {
+			return err
+		}
+	}
+
+	report.ComputeStats(r.averageRounding)
+
+	// Check again with the new values so a worker cannot hand the report to someone else
+	if _, err := authorizeReportFromContext(ctx, ActionUpdateReport, report); err != nil {
+		return err
+	}
+
+	if action != model.RevisionActionRestore {
+		if err := r.checkAssignment(ctx, report, before); err != nil {
+			return err
+		}
+	}
+
+	return r.checkReportTaken(ctx, report.Id, report.MonthOf, report.WorkerId)
+}
+
+// write stores a checked report and the revision of action in tx.
+func (r *ReportServiceImpl) write(ctx context.Context, tx *sql.Tx, action string, report, before *model.Report) error {
+	if err := r.reportRepository.Update(ctx, tx, report); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrReportLocked
+		}
+		return err
+	}
+	return r.recordRevision(ctx, tx, action, before, report)
 }
 
 func (r *ReportServiceImpl) ExportReportToExcel(ctx context.Context, id int) (string, error) {
@@ -250,13 +292,26 @@ func (r *ReportServiceImpl) ExportReportToExcel(ctx context.Context, id int) (st
 		return "", err
 	}
 
+	catalog, err := r.activityRepository.FindAll(ctx)
+	if err != nil {
+		return "", err
+	}
+	activities := catalog.ForReport(reportResp)
+
 	f := excelize.NewFile()
 	sheet := "Report"
 	f.NewSheet(sheet)
 	f.DeleteSheet("Sheet1")
 
 	// Headers
-	headers := []string{"ID", "Month Of", "Worker Name", "Area Of Assignment", "Name Of Church", "Worship Service", "Sunday School", "Prayer Meetings", "Bible Studies", "Mens Fellowships", "Womens Fellowships", "Youth Fellowships", "Child Fellowships", "Outreach", "Training Or Seminars", "Leadership Conferences", "Leadership Training", "Others", "Family Days", "Tithes And Offerings", "Home Visited", "Bible Study Or Group Led", "Sermon Or Message Preached", "Person Newly Contacted", "Person Followed Up", "Person Led To Christ", "Names", "Narrative Report", "Challenges And Problem Encountered", "Prayer Request", "Created At", "Updated At", "Worship Service Avg", "Sunday School Avg", "Prayer Meetings Avg", "Bible Studies Avg", "Mens Fellowships Avg", "Womens Fellowships Avg", "Youth Fellowships Avg", "Child Fellowships Avg", "Outreach Avg", "Training Or Seminars Avg", "Leadership Conferences Avg", "Leadership Training Avg", "Others Avg", "Family Days Avg", "Tithes And Offerings Avg", "Home Visited Avg", "Bible Study Or Group Led Avg", "Sermon Or Message Preached Avg", "Person Newly Contacted Avg", "Person Followed Up Avg", "Person Led To Christ Avg"}
+	headers := []string{"ID", "Month Of", "Worker Name", "Area Of Assignment", "Name Of Church"}
+	for _, activity := range activities {
+		headers = append(headers, activity.Label)
+	}
+	headers = append(headers, "Names", "Narrative Report", "Challenges And Problem Encountered", "Prayer Request", "Created At", "Updated At")
+	for _, activity := range activities {
+		headers = append(headers, activity.Label+" Avg")
+	}
 
 	for col, header := range headers {
 		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
@@ -264,10 +319,13 @@ func (r *ReportServiceImpl) ExportReportToExcel(ctx context.Context, id int) (st
 	}
 
 	// Values
-	values := []interface{}{
-		reportResp.Id, reportResp.MonthOf, reportResp.WorkerName, reportResp.AreaOfAssignment, reportResp.NameOfChurch,
-		reportResp.WorshipService, reportResp.SundaySchool, reportResp.PrayerMeetings, reportResp.BibleStudies, reportResp.MensFellowships, reportResp.WomensFellowships, reportResp.YouthFellowships, reportResp.ChildFellowships, reportResp.Outreach, reportResp.TrainingOrSeminars, reportResp.LeadershipConferences, reportResp.LeadershipTraining, reportResp.Others, reportResp.FamilyDays, reportResp.TithesAndOfferings, reportResp.HomeVisited, reportResp.BibleStudyOrGroupLed, reportResp.SermonOrMessagePreached, reportResp.PersonNewlyContacted, reportResp.PersonFollowedUp, reportResp.PersonLedToChrist, reportResp.Names, reportResp.NarrativeReport, reportResp.ChallengesAndProblemEncountered, reportResp.PrayerRequest, reportResp.CreatedAt, reportResp.UpdatedAt,
-		reportResp.WorshipServiceAvg, reportResp.SundaySchoolAvg, reportResp.PrayerMeetingsAvg, reportResp.BibleStudiesAvg, reportResp.MensFellowshipsAvg, reportResp.WomensFellowshipsAvg, reportResp.YouthFellowshipsAvg, reportResp.ChildFellowshipsAvg, reportResp.OutreachAvg, reportResp.TrainingOrSeminarsAvg, reportResp.LeadershipConferencesAvg, reportResp.LeadershipTrainingAvg, reportResp.OthersAvg, reportResp.FamilyDaysAvg, reportResp.TithesAndOfferingsAvg, reportResp.HomeVisitedAvg, reportResp.BibleStudyOrGroupLedAvg, reportResp.SermonOrMessagePreachedAvg, reportResp.PersonNewlyContactedAvg, reportResp.PersonFollowedUpAvg, reportResp.PersonLedToChristAvg,
+	values := []interface{}{reportResp.Id, reportResp.MonthOf.Label(), reportResp.WorkerName, reportResp.AreaOfAssignment, reportResp.NameOfChurch}
+	for _, activity := range activities {
+		values = append(values, reportResp.Activities[activity.Key])
+	}
+	values = append(values, reportResp.Names, reportResp.NarrativeReport, reportResp.ChallengesAndProblemEncountered, reportResp.PrayerRequest, reportResp.CreatedAt, reportResp.UpdatedAt)
+	for _, activity := range activities {
+		values = append(values, reportResp.Stats[activity.Key].Average)
 	}
 
 	for col, value := range values {
@@ -282,3 +340,125 @@ func (r *ReportServiceImpl) ExportReportToExcel(ctx context.Context, id int) (st
 
 	return filePath, nil
 }
+
+// StreamReports calls fn for every report matching query that the caller may
+// read without loading them all into memory.
+func (r *ReportServiceImpl) StreamReports(ctx context.Context, query *model.SearchReportQuery, fn func(report *model.Report) error) error {
+	user, ok := helper.CurrentUser(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	if err := scopeReportQuery(user, query); err != nil {
+		return err
+	}
+
+	return r.reportRepository.Stream(ctx, query, fn)
+}
+
+// checkAssignment checks that the worker, area and church of report exist
+// and fit together: the area must be the worker's area of assignment and
+// the church must be in that area. Supervisors are scoped by the area, so
+// a report must not be filed outside it. The names are filled in for the
+// revision snapshot. Reports whose assignment did not change since before
+// are left alone, as the worker may have moved since.
+func (r *ReportServiceImpl) checkAssignment(ctx context.Context, report, before *model.Report) error {
+	if before != nil && before.WorkerId == report.WorkerId && before.AreaId == report.AreaId && before.ChurchId == report.ChurchId {
+		return nil
+	}
+
+	worker, err := r.workerRepository.FindById(ctx, report.WorkerId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: worker %d", ErrReferenceNotFound, report.WorkerId)
+	}
+	if err != nil {
+		return err
+	}
+
+	report.WorkerName = worker.Name
+
+	if worker.AreaId > 0 {
+		if report.AreaId != worker.AreaId {
+			return ErrAreaNotAssigned
+		}
+		report.AreaOfAssignment = worker.AreaName
+	} else {
+		area, err := r.areaRepository.FindById(ctx, report.AreaId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: area %d", ErrReferenceNotFound, report.AreaId)
+		}
+		if err != nil {
+			return err
+		}
+		report.AreaOfAssignment = area.Name
+	}
+
+	church, err := checkChurchInArea(ctx, r.churchRepository, report.ChurchId, report.AreaId)
+	if err != nil {
+		return err
+	}
+	report.NameOfChurch = ""
+	if church != nil {
+		report.NameOfChurch = church.Name
+	}
+
+	return nil
+}
+
+// checkReportTaken fails with a ReportConflictError when the worker already
+// filed another report for monthOf.
+func (r *ReportServiceImpl) checkReportTaken(ctx context.Context, id int, monthOf model.Period, workerId int) error {
+	existing, err := r.reportRepository.ReportTaken(ctx, id, monthOf, workerId)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return &ReportConflictError{ExistingId: existing.Id}
+	}
+
+	return nil
+}
+
+// reportTakenError explains a unique violation raised by a concurrent write
+// that slipped past checkReportTaken.
+func (r *ReportServiceImpl) reportTakenError(ctx context.Context, id int, monthOf model.Period, workerId int) error {
+	if err := r.checkReportTaken(ctx, id, monthOf, workerId); err != nil {
+		return err
+	}
+	return ErrReportTaken
+}
+
+// checkActivities makes sure every activity of report is in the catalog.
+// Inactive activities are only accepted when before already had them, so
+// reports filed before an activity was retired can still be edited.
+func (r *ReportServiceImpl) checkActivities(ctx context.Context, report, before *model.Report) error {
+	catalog, err := r.activityRepository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var existing model.ActivityValues
+	if before != nil {
+		existing = before.Activities
+	}
+
+	return checkCatalog(catalog, report.Activities, existing)
+}
+
+// checkCatalog is checkActivities against a catalog the caller already
+// loaded.
+func checkCatalog(catalog model.ActivityCatalog, activities, existing model.ActivityValues) error {
+	for key := range activities {
+		activity := catalog.Find(key)
+		if activity == nil {
+			return fmt.Errorf("%w: %s", ErrUnknownActivity, key)
+		}
+
+		if _, had := existing[key]; !activity.Active && !had {
+			return fmt.Errorf("%w: %s", ErrInactiveActivity, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"reports/model"
+)
+
+// RecomputeStats works out the stored stats of every report again, trashed
+// ones included, with the configured rounding. Stats are only computed when
+// a report is written, so this brings old reports in line after the
+// rounding changed. It returns the number of reports whose stats changed.
+func (r *ReportServiceImpl) RecomputeStats(ctx context.Context) (int, error) {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, deleted := range []bool{false, true} {
+		err := r.reportRepository.Stream(ctx, &model.SearchReportQuery{Deleted: deleted}, func(report *model.Report) error {
+			stored := report.Stats
+			report.ComputeStats(r.averageRounding)
+			if reflect.DeepEqual(stored, report.Stats) {
+				return nil
+			}
+
+			if err := r.reportRepository.UpdateStats(ctx, report.Id, report.Stats); err != nil {
+				return fmt.Errorf("report %d: %w", report.Id, err)
+			}
+			changed++
+			return nil
+		})
+		if err != nil {
+			return changed, err
+		}
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reports/helper"
+	"reports/model"
+	"reports/repository"
+	"testing"
+)
+
+// statsReports streams stored live and trashed reports and records the
+// stats written back.
+type statsReports struct {
+	repository.ReportRepository
+	live, trashed []*model.Report
+	updated       map[int]model.ReportStats
+}
+
+func (s *statsReports) Stream(ctx context.Context, query *model.SearchReportQuery, fn func(report *model.Report) error) error {
+	reports := s.live
+	if query.Deleted {
+		reports = s.trashed
+	}
+	for _, report := range reports {
+		if err := fn(report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *statsReports) UpdateStats(ctx context.Context, reportId int, stats model.ReportStats) error {
+	s.updated[reportId] = stats
+	return nil
+}
+
+func TestRecomputeStats(t *testing.T) {
+	halfUp := model.DefaultAverageRounding
+	down := model.AverageRounding{Mode: model.RoundDown, Precision: 1}
+
+	// 52 over 3 weeks is 17.33 half up to two decimals but 17.3 rounded down
+	// to one; 40 over 2 weeks is 20 either way
+	stored := func(id int, values ...int) *model.Report {
+		report := &model.Report{Id: id, Activities: model.ActivityValues{"worship_service": values}}
+		report.ComputeStats(halfUp)
+		return report
+	}
+	reports := &statsReports{
+		live:    []*model.Report{stored(1, 10, 20, 22), stored(2, 20, 20)},
+		trashed: []*model.Report{stored(3, 10, 20, 22)},
+		updated: map[int]model.ReportStats{},
+	}
+	service := &ReportServiceImpl{reportRepository: reports, averageRounding: down}
+
+	ctx := helper.WithCurrentUser(context.Background(), &model.User{Id: 1, Role: model.RoleNationalAdmin})
+	changed, err := service.RecomputeStats(ctx)
+	if err != nil {
+		t.Fatalf("RecomputeStats() error = %v", err)
+	}
+
+	if changed != 2 || len(reports.updated) != 2 {
+		t.Fatalf("changed %d, updated %v, want reports 1 and 3", changed, reports.updated)
+	}
+	for _, id := range []int{1, 3} {
+		if got := reports.updated[id]["worship_service"].Average; got != 17.3 {
+			t.Errorf("report %d average = %v, want 17.3", id, got)
+		}
+	}
+
+	worker := helper.WithCurrentUser(context.Background(), &model.User{Id: 2, Role: model.RoleWorker, WorkerId: 10})
+	if _, err := service.RecomputeStats(worker); !errors.Is(err, ErrForbidden) {
+		t.Errorf("RecomputeStats() as a worker error = %v, want %v", err, ErrForbidden)
+	}
+}
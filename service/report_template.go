@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"reports/model"
+	"time"
+)
+
+// Template returns the details printed on a blank report for the worker and
+// month, for anyone who may file that worker's report. A workerId of 0 means
+// the worker of the signed-in user and an empty month the current one.
+func (r *ReportServiceImpl) Template(ctx context.Context, workerId int, month string) (*model.Report, error) {
+	user, err := requireRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if workerId <= 0 {
+		workerId = user.WorkerId
+	}
+	if workerId <= 0 {
+		return nil, ErrWorkerRequired
+	}
+
+	var monthOf model.Period
+	if month == "" {
+		monthOf = model.PeriodOf(time.Now().In(model.Manila))
+	} else if monthOf, err = model.ParsePeriod(month); err != nil {
+		return nil, err
+	}
+
+	worker, err := r.workerRepository.FindById(ctx, workerId)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.Report{
+		MonthOf:          monthOf,
+		WorkerId:         worker.Id,
+		WorkerName:       worker.Name,
+		AreaId:           worker.AreaId,
+		AreaOfAssignment: worker.AreaName,
+		ChurchId:         worker.ChurchId,
+		NameOfChurch:     worker.ChurchName,
+	}
+
+	if _, err := authorizeReportFromContext(ctx, ActionCreateReport, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
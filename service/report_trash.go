@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"reports/helper"
+	"reports/model"
+)
+
+// Trash lists deleted reports that have not been purged yet. Only national
+// admins may see it, as they are the only ones who can delete.
+func (r *ReportServiceImpl) Trash(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error) {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return nil, err
+	}
+
+	query.Deleted = true
+
+	if query.Page <= 0 {
+		query.Page = r.paginationConfig.Page
+	}
+	if query.PerPage <= 0 {
+		query.PerPage = r.paginationConfig.PageLimit
+	}
+
+	return r.reportRepository.FindAll(ctx, query)
+}
+
+// Restore takes a report out of the trash, unless the worker has filed
+// another report for the same month in the meantime.
+func (r *ReportServiceImpl) Restore(ctx context.Context, reportId int) error {
+	report, err := r.reportRepository.FindDeletedById(ctx, reportId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := authorizeReportFromContext(ctx, ActionDeleteReport, report); err != nil {
+		return err
+	}
+
+	if err := r.checkReportTaken(ctx, report.Id, report.MonthOf, report.WorkerId); err != nil {
+		return err
+	}
+
+	before := *report
+	report.DeletedAt, report.DeletedBy = nil, 0
+
+	err = r.inTx(ctx, func(tx *sql.Tx) error {
+		if err := r.reportRepository.Restore(ctx, tx, report.Id); err != nil {
+			return err
+		}
+		return r.recordRevision(ctx, tx, model.RevisionActionUndelete, &before, report)
+	})
+	if helper.IsUniqueViolation(err) {
+		return r.reportTakenError(ctx, report.Id, report.MonthOf, report.WorkerId)
+	}
+	return err
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reports/data/request"
+	"reports/model"
+	"time"
+)
+
+// reportTransitions lists, for every target status, the statuses a report
+// may move to it from.
+var reportTransitions = map[string][]string{
+	model.ReportStatusSubmitted: {model.ReportStatusDraft, model.ReportStatusReturned},
+	model.ReportStatusApproved:  {model.ReportStatusSubmitted},
+	model.ReportStatusReturned:  {model.ReportStatusSubmitted},
+}
+
+func canTransition(from, to string) bool {
+	for _, allowed := range reportTransitions[to] {
+		if allowed == from {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReportServiceImpl) Submit(ctx context.Context, reportId int) error {
+	report, err := r.reportRepository.FindById(ctx, reportId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := authorizeReportFromContext(ctx, ActionUpdateReport, report); err != nil {
+		return err
+	}
+
+	if !canTransition(report.Status, model.ReportStatusSubmitted) {
+		return ErrInvalidTransition
+	}
+
+	before := *report
+	now := time.Now().UTC()
+
+	report.Status = model.ReportStatusSubmitted
+	report.SubmittedAt = &now
+	report.UpdatedAt = now
+
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if err := r.reportRepository.UpdateStatus(ctx, tx, report, before.Status); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidTransition
+			}
+			return err
+		}
+		return r.recordRevision(ctx, tx, model.RevisionActionSubmit, &before, report)
+	})
+}
+
+func (r *ReportServiceImpl) Approve(ctx context.Context, request *request.ReportApproveRequest) error {
+	return r.review(ctx, request.Id, model.ReportStatusApproved, request.Comment)
+}
+
+func (r *ReportServiceImpl) Return(ctx context.Context, request *request.ReportReturnRequest) error {
+	return r.review(ctx, request.Id, model.ReportStatusReturned, request.Comment)
+}
+
+// review moves a submitted report to status on behalf of the supervisor in
+// ctx and records their comment.
+func (r *ReportServiceImpl) review(ctx context.Context, reportId int, status, comment string) error {
+	report, err := r.reportRepository.FindById(ctx, reportId)
+	if err != nil {
+		return err
+	}
+
+	user, err := authorizeReportFromContext(ctx, ActionReviewReport, report)
+	if err != nil {
+		return err
+	}
+
+	if !canTransition(report.Status, status) {
+		return ErrInvalidTransition
+	}
+
+	before := *report
+	now := time.Now().UTC()
+
+	report.Status = status
+	report.ReviewComment = comment
+	report.ReviewedAt = &now
+	report.ReviewedBy = user.Id
+	report.UpdatedAt = now
+
+	action := model.RevisionActionApprove
+	if status == model.ReportStatusReturned {
+		action = model.RevisionActionReturn
+	}
+
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if err := r.reportRepository.UpdateStatus(ctx, tx, report, before.Status); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidTransition
+			}
+			return err
+		}
+		return r.recordRevision(ctx, tx, action, &before, report)
+	})
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reports/helper"
+	"reports/model"
+	"reports/repository"
+	"testing"
+)
+
+var statuses = []string{model.ReportStatusDraft, model.ReportStatusSubmitted, model.ReportStatusApproved, model.ReportStatusReturned}
+
+func TestCanTransition(t *testing.T) {
+	allowed := map[[2]string]bool{
+		{model.ReportStatusDraft, model.ReportStatusSubmitted}:    true,
+		{model.ReportStatusReturned, model.ReportStatusSubmitted}: true,
+		{model.ReportStatusSubmitted, model.ReportStatusApproved}: true,
+		{model.ReportStatusSubmitted, model.ReportStatusReturned}: true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			if got, want := canTransition(from, to), allowed[[2]string{from, to}]; got != want {
+				t.Errorf("canTransition(%q, %q) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+// errWriteReached stands in for the transaction, so a test can tell a
+// review that passed its checks from one turned down before writing.
+var errWriteReached = errors.New("write reached")
+
+type stubReports struct {
+	repository.ReportRepository
+	report *model.Report
+}
+
+func (s stubReports) FindById(ctx context.Context, reportId int) (*model.Report, error) {
+	if s.report == nil || s.report.Id != reportId {
+		return nil, sql.ErrNoRows
+	}
+	report := *s.report
+	return &report, nil
+}
+
+func (s stubReports) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return nil, errWriteReached
+}
+
+func TestReview(t *testing.T) {
+	admin := &model.User{Id: 1, Role: model.RoleNationalAdmin}
+	ctx := helper.WithCurrentUser(context.Background(), admin)
+
+	for _, from := range statuses {
+		for _, to := range []string{model.ReportStatusApproved, model.ReportStatusReturned} {
+			service := &ReportServiceImpl{reportRepository: stubReports{report: &model.Report{Id: 5, WorkerId: 10, AreaId: 1, Status: from}}}
+
+			want := ErrInvalidTransition
+			if from == model.ReportStatusSubmitted {
+				want = errWriteReached
+			}
+			if err := service.review(ctx, 5, to, "ok"); !errors.Is(err, want) {
+				t.Errorf("review from %q to %q: error = %v, want %v", from, to, err, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// txDriver is a database/sql driver whose connections only begin, commit
+// and roll back transactions and accept savepoints, so tests can run service code that groups
+// writes in a real *sql.Tx while the repositories are stubbed.
+type txDriver struct{}
+
+func (txDriver) Open(name string) (driver.Conn, error) { return txConn{}, nil }
+
+type txConn struct{}
+
+func (txConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("txDriver runs no statements")
+}
+func (txConn) Exec(query string, args []driver.Value) (driver.Result, error) {
+	// Savepoints are all the service runs on a transaction itself
+	return driver.RowsAffected(0), nil
+}
+func (txConn) Close() error              { return nil }
+func (txConn) Begin() (driver.Tx, error) { return txConn{}, nil }
+func (txConn) Commit() error             { return nil }
+func (txConn) Rollback() error           { return nil }
+
+func init() {
+	sql.Register("service_test_tx", txDriver{})
+}
+
+// txDB hands out transactions of txDriver.
+type txDB struct {
+	db *sql.DB
+}
+
+func newTxDB() txDB {
+	db, _ := sql.Open("service_test_tx", "")
+	return txDB{db: db}
+}
+
+func (d txDB) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return d.db.BeginTx(ctx, nil)
+}
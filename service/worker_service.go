@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"reports/data/request"
+	"reports/model"
+)
+
+type WorkerService interface {
+	Create(ctx context.Context, request *request.WorkerCreateRequest) (*model.Worker, error)
+	Update(ctx context.Context, request *request.WorkerUpdateRequest) error
+	Delete(ctx context.Context, workerId int) error
+	FindById(ctx context.Context, workerId int) (*model.Worker, error)
+	FindAll(ctx context.Context, areaId int) ([]*model.Worker, error)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reports/data/request"
+	"reports/helper"
+	"reports/model"
+	"reports/repository"
+	"time"
+)
+
+type WorkerServiceImpl struct {
+	workerRepository repository.WorkerRepository
+	churchRepository repository.ChurchRepository
+}
+
+func NewWorkerServiceImpl(workerRepository repository.WorkerRepository, churchRepository repository.ChurchRepository) WorkerService {
+	return &WorkerServiceImpl{workerRepository: workerRepository, churchRepository: churchRepository}
+}
+
+func (w *WorkerServiceImpl) Create(ctx context.Context, request *request.WorkerCreateRequest) (*model.Worker, error) {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return nil, err
+	}
+
+	if _, err := checkChurchInArea(ctx, w.churchRepository, request.ChurchId, request.AreaId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	worker := model.Worker{
+		Name:      model.NormalizeName(request.Name),
+		AreaId:    request.AreaId,
+		ChurchId:  request.ChurchId,
+		Active:    request.Active == nil || *request.Active,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := w.workerRepository.Save(ctx, &worker); err != nil {
+		if helper.IsUniqueViolation(err) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to save worker: %w", err)
+	}
+
+	return w.workerRepository.FindById(ctx, worker.Id)
+}
+
+func (w *WorkerServiceImpl) Update(ctx context.Context, request *request.WorkerUpdateRequest) error {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return err
+	}
+
+	worker, err := w.workerRepository.FindById(ctx, request.Id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := checkChurchInArea(ctx, w.churchRepository, request.ChurchId, request.AreaId); err != nil {
+		return err
+	}
+
+	worker.Name = model.NormalizeName(request.Name)
+	worker.AreaId = request.AreaId
+	worker.ChurchId = request.ChurchId
+	if request.Active != nil {
+		worker.Active = *request.Active
+	}
+	worker.UpdatedAt = time.Now().UTC()
+
+	if err := w.workerRepository.Update(ctx, worker); err != nil {
+		if helper.IsUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (w *WorkerServiceImpl) Delete(ctx context.Context, workerId int) error {
+	if _, err := requireRole(ctx, model.RoleNationalAdmin); err != nil {
+		return err
+	}
+
+	worker, err := w.workerRepository.FindById(ctx, workerId)
+	if err != nil {
+		return err
+	}
+
+	if err := w.workerRepository.Delete(ctx, worker.Id); err != nil {
+		if helper.IsForeignKeyViolation(err) {
+			return ErrInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (w *WorkerServiceImpl) FindById(ctx context.Context, workerId int) (*model.Worker, error) {
+	if _, err := requireRole(ctx); err != nil {
+		return nil, err
+	}
+
+	return w.workerRepository.FindById(ctx, workerId)
+}
+
+func (w *WorkerServiceImpl) FindAll(ctx context.Context, areaId int) ([]*model.Worker, error) {
+	if _, err := requireRole(ctx); err != nil {
+		return nil, err
+	}
+
+	return w.workerRepository.FindAll(ctx, areaId)
+}
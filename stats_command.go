@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"reports/helper"
+	"reports/repository"
+	"reports/service"
+)
+
+const statsUsage = "usage: recompute-stats [-user USERNAME]"
+
+// runStatsCommand runs "recompute-stats", storing the stats of every report
+// again with the configured rounding, as the bootstrap admin unless -user
+// is set.
+func runStatsCommand(ctx context.Context, reportService service.ReportService, userRepository repository.UserRepository, adminUsername string, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("recompute-stats", flag.ContinueOnError)
+	flags.SetOutput(out)
+	username := flags.String("user", adminUsername, "user the stats are recomputed as")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return errors.New(statsUsage)
+	}
+
+	user, err := userRepository.FindByUsername(ctx, *username)
+	if err != nil {
+		return fmt.Errorf("cannot find user %q: %w", *username, err)
+	}
+
+	changed, err := reportService.RecomputeStats(helper.WithCurrentUser(ctx, user))
+	fmt.Fprintf(out, "updated the stats of %d report(s)\n", changed)
+	return err
+}
@@ -0,0 +1,29 @@
+package utils
+
+import "reports/model"
+
+// ReportActivity is one line of the weekly attendance table as it is printed
+// on the monthly report.
+type ReportActivity struct {
+	Key     string
+	Label   string
+	Values  []int
+	Average float64
+}
+
+// ReportActivities lists a line for every activity of catalog, in catalog
+// order, with the values and average report has for it. Printed reports pass
+// catalog.ForReport(report); column layouts pass the same catalog for every
+// report so the columns line up.
+func ReportActivities(catalog model.ActivityCatalog, report *model.Report) []ReportActivity {
+	activities := make([]ReportActivity, len(catalog))
+	for i, activity := range catalog {
+		activities[i] = ReportActivity{
+			Key:     activity.Key,
+			Label:   activity.Label + ":",
+			Values:  report.Activities[activity.Key],
+			Average: report.Stats[activity.Key].Average,
+		}
+	}
+	return activities
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reports/model"
+	"strconv"
+	"strings"
+)
+
+// ReportRow is a report read from one line of a CSV file with the columns of
+// ReportExportColumns. Workers, areas and churches may be given by id or by
+// name; columns the import does not use, like id, status and the averages,
+// are ignored so an export can be imported again.
+type ReportRow struct {
+	Line       int
+	MonthOf    string
+	WorkerId   int
+	WorkerName string
+	AreaId     int
+	AreaName   string
+	ChurchId   int
+	ChurchName string
+
+	// Report carries the month, activities, names and text sections.
+	Report model.Report
+
+	Errors []model.ImportError
+}
+
+func (r *ReportRow) addError(field, message string) {
+	r.Errors = append(r.Errors, model.ImportError{Line: r.Line, Field: field, Message: message})
+}
+
+// ParseReportCSV reads every line after the header of a CSV report file,
+// taking the weekly values of the activities of catalog from their
+// <key>_w1..w5 columns. Problems with a single value are recorded on its row;
+// an error is only returned when the file itself cannot be read.
+func ParseReportCSV(in io.Reader, catalog model.ActivityCatalog) ([]*ReportRow, error) {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, seen := columns[name]; !seen {
+			columns[name] = i
+		}
+	}
+
+	if _, ok := columns["month_of"]; !ok {
+		return nil, errors.New("header has no month_of column")
+	}
+	_, hasWorkerId := columns["worker_id"]
+	_, hasWorkerName := columns["worker_name"]
+	if !hasWorkerId && !hasWorkerName {
+		return nil, errors.New("header has neither a worker_id nor a worker_name column")
+	}
+
+	var rows []*ReportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseReportRecord(columns, catalog, record, line))
+	}
+
+	return rows, nil
+}
+
+func parseReportRecord(columns map[string]int, catalog model.ActivityCatalog, record []string, line int) *ReportRow {
+	row := &ReportRow{Line: line}
+
+	get := func(column string) string {
+		index, ok := columns[column]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	id := func(column string) int {
+		text := get(column)
+		if text == "" {
+			return 0
+		}
+		value, err := strconv.Atoi(text)
+		if err != nil || value < 0 {
+			row.addError(column, fmt.Sprintf("%s must be a positive whole number, got %q", column, text))
+			return 0
+		}
+		return value
+	}
+
+	row.MonthOf = get("month_of")
+	if period, err := model.ParsePeriod(row.MonthOf); err == nil {
+		row.Report.MonthOf = period
+	}
+
+	row.WorkerId = id("worker_id")
+	row.WorkerName = get("worker_name")
+	row.AreaId = id("area_id")
+	row.AreaName = get("area_of_assignment")
+	row.ChurchId = id("church_id")
+	row.ChurchName = get("name_of_church")
+
+	row.Report.Activities = model.ActivityValues{}
+	for _, activity := range catalog {
+		cells := make([]string, model.ReportWeeks)
+		for week := 1; week <= model.ReportWeeks; week++ {
+			cells[week-1] = get(fmt.Sprintf("%s_w%d", activity.Key, week))
+		}
+
+		values := parseWeekValues(cells, func(week int, message string) {
+			row.addError(fmt.Sprintf("%s_w%d", activity.Key, week), message)
+		})
+		if len(values) > 0 {
+			row.Report.Activities[activity.Key] = values
+		}
+	}
+
+	for _, name := range strings.Split(get("names"), ";") {
+		if name = strings.TrimSpace(name); name != "" {
+			row.Report.Names = append(row.Report.Names, name)
+		}
+	}
+
+	row.Report.NarrativeReport = get("narrative_report")
+	row.Report.ChallengesAndProblemEncountered = get("challenges_and_problem_encountered")
+	row.Report.PrayerRequest = get("prayer_request")
+
+	return row
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseReportCSV(t *testing.T) {
+	input := "month_of,worker_name,worship_service_w1,worship_service_w2,worship_service_w3,narrative_report\n" +
+		"2024-01,Juan,10,,12,\"two\nlines\"\n" +
+		"2024-02,Juan,ten,-1,,ok\n"
+
+	rows, err := ParseReportCSV(strings.NewReader(input), testCatalog)
+	if err != nil {
+		t.Fatalf("ParseReportCSV() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("ParseReportCSV() returned %d rows, want 2", len(rows))
+	}
+
+	first := rows[0]
+	if first.Line != 2 || len(first.Errors) != 0 {
+		t.Fatalf("first row: line %d, errors %v", first.Line, first.Errors)
+	}
+	if want := []int{10, 0, 12}; !reflect.DeepEqual(first.Report.Activities["worship_service"], want) {
+		t.Fatalf("first row worship service = %v, want %v", first.Report.Activities["worship_service"], want)
+	}
+	if first.Report.NarrativeReport != "two\nlines" {
+		t.Fatalf("first row narrative = %q", first.Report.NarrativeReport)
+	}
+
+	// The quoted line break above moves the second row to line 4
+	second := rows[1]
+	if second.Line != 4 {
+		t.Fatalf("second row line = %d, want 4", second.Line)
+	}
+
+	var fields []string
+	for _, importError := range second.Errors {
+		if importError.Line != 4 {
+			t.Fatalf("error %v has line %d, want 4", importError, importError.Line)
+		}
+		fields = append(fields, importError.Field)
+	}
+	if want := []string{"worship_service_w1", "worship_service_w2"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("second row error fields = %v, want %v", fields, want)
+	}
+}
+
+func TestParseReportCSVHeader(t *testing.T) {
+	for _, input := range []string{"", "worker_name\nJuan\n", "month_of\n2024-01\n"} {
+		if _, err := ParseReportCSV(strings.NewReader(input), testCatalog); err == nil {
+			t.Fatalf("ParseReportCSV(%q) error = nil, want an error", input)
+		}
+	}
+}
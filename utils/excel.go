@@ -9,7 +9,9 @@ import (
 	"github.com/tealeg/xlsx"
 )
 
-func AddReportToSheet(sheet *xlsx.Sheet, report *model.Report) {
+// AddReportToSheet lays report out on sheet the way the monthly report is
+// printed, with a line for every activity of catalog.ForReport(report).
+func AddReportToSheet(sheet *xlsx.Sheet, catalog model.ActivityCatalog, report *model.Report) {
 	// Set specific column widths
 	const (
 		orgNameWidth = 35
@@ -41,7 +43,7 @@ func AddReportToSheet(sheet *xlsx.Sheet, report *model.Report) {
 	}
 
 	// Add report data
-	AddRow(sheet, "Month Of:", report.MonthOf, 120)
+	AddRow(sheet, "Month Of:", report.MonthOf.Label(), 120)
 	AddRow(sheet, "Worker Name:", report.WorkerName, 120)
 	AddRow(sheet, "Area Of Assignment:", report.AreaOfAssignment, 120)
 	AddRow(sheet, "Name Of Church:", report.NameOfChurch, 120)
@@ -55,7 +57,7 @@ func AddReportToSheet(sheet *xlsx.Sheet, report *model.Report) {
 
 	// Add Weekly Attendance headers
 	activityRow = sheet.AddRow()
-	AddMergedCell(activityRow, "Activities", 1, 1)
+	addCellWithStyle(activityRow, "Activities", true)
 	addCellWithStyle(activityRow, "Week 1", true)
 	addCellWithStyle(activityRow, "Week 2", true)
 	addCellWithStyle(activityRow, "Week 3", true)
@@ -69,28 +71,9 @@ func AddReportToSheet(sheet *xlsx.Sheet, report *model.Report) {
 	}
 
 	// Add arrays with averages
-	AddActivityRow(sheet, "Worship Service:", report.WorshipService, report.WorshipServiceAvg)
-	AddActivityRow(sheet, "Sunday School:", report.SundaySchool, report.SundaySchoolAvg)
-	AddActivityRow(sheet, "Prayer Meetings:", report.PrayerMeetings, report.PrayerMeetingsAvg)
-	AddActivityRow(sheet, "Bible Studies:", report.BibleStudies, report.BibleStudiesAvg)
-	AddActivityRow(sheet, "Mens Fellowships:", report.MensFellowships, report.MensFellowshipsAvg)
-	AddActivityRow(sheet, "Womens Fellowships:", report.WomensFellowships, report.WomensFellowshipsAvg)
-	AddActivityRow(sheet, "Youth Fellowships:", report.YouthFellowships, report.YouthFellowshipsAvg)
-	AddActivityRow(sheet, "Child Fellowships:", report.ChildFellowships, report.ChildFellowshipsAvg)
-	AddActivityRow(sheet, "Outreach:", report.Outreach, report.OutreachAvg)
-	AddActivityRow(sheet, "Training Or Seminars:", report.TrainingOrSeminars, report.TrainingOrSeminarsAvg)
-	AddActivityRow(sheet, "Leadership Conferences:", report.LeadershipConferences, report.LeadershipConferencesAvg)
-	AddActivityRow(sheet, "Leadership Training:", report.LeadershipTraining, report.LeadershipTrainingAvg)
-	AddActivityRow(sheet, "Others:", report.Others, report.OthersAvg)
-	AddActivityRow(sheet, "Family Days:", report.FamilyDays, report.FamilyDaysAvg)
-	AddActivityRow(sheet, "Tithes And Offerings:", report.TithesAndOfferings, report.TithesAndOfferingsAvg)
-
-	AddActivityRow(sheet, "Home Visited:", report.HomeVisited, report.HomeVisitedAvg)
-	AddActivityRow(sheet, "Bible Study Group Led:", report.BibleStudyOrGroupLed, report.BibleStudyOrGroupLedAvg)
-	AddActivityRow(sheet, "Sermon/\nMessage Preached:", report.SermonOrMessagePreached, report.SermonOrMessagePreachedAvg)
-	AddActivityRow(sheet, "Person Newly Contacted:", report.PersonNewlyContacted, report.PersonNewlyContactedAvg)
-	AddActivityRow(sheet, "Person Followed-Up:", report.PersonFollowedUp, report.PersonFollowedUpAvg)
-	AddActivityRow(sheet, "Person Led To Christ:", report.PersonLedToChrist, report.PersonLedToChristAvg)
+	for _, activity := range ReportActivities(catalog.ForReport(report), report) {
+		AddActivityRow(sheet, activity.Label, activity.Values, activity.Average)
+	}
 
 	// Add Names as a comma-separated list
 	if len(report.Names) > 0 {
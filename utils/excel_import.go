@@ -0,0 +1,239 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reports/model"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// addRowWrapWidth is the width at which AddRow breaks long text; the import
+// joins those breaks back together.
+const addRowWrapWidth = 120
+
+// ReportSheet is a report read from a workbook sheet laid out like
+// AddReportToSheet. Names are kept as written; resolving them to ids is up
+// to the caller, which can point at the cells in WorkerCell, AreaCell and
+// ChurchCell when they do not match.
+type ReportSheet struct {
+	Sheet      string
+	MonthOf    string
+	MonthCell  string
+	WorkerName string
+	WorkerCell string
+	AreaName   string
+	AreaCell   string
+	ChurchName string
+	ChurchCell string
+
+	// Report carries the activities, names and text sections.
+	Report model.Report
+
+	Errors []model.ImportError
+}
+
+func (s *ReportSheet) addError(cell, field, message string) {
+	s.Errors = append(s.Errors, model.ImportError{Sheet: s.Sheet, Cell: cell, Field: field, Message: message})
+}
+
+// ParseReportWorkbook reads every sheet of a workbook that has a "Month Of:"
+// label, so both the single report export and the multi-report workbook can
+// be imported. Labels are matched ignoring case, spacing and punctuation.
+// Activities of catalog without a row on the sheet are left out, so sheets
+// made before an activity was added can still be read.
+func ParseReportWorkbook(in io.Reader, catalog model.ActivityCatalog) ([]*ReportSheet, error) {
+	file, err := excelize.OpenReader(in)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read workbook: %w", err)
+	}
+	defer file.Close()
+
+	var sheets []*ReportSheet
+	for _, name := range file.GetSheetList() {
+		rows, err := file.GetRows(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if sheet := parseReportSheet(name, rows, catalog); sheet != nil {
+			sheets = append(sheets, sheet)
+		}
+	}
+
+	return sheets, nil
+}
+
+func parseReportSheet(name string, rows [][]string, catalog model.ActivityCatalog) *ReportSheet {
+	labels := map[string]int{}
+	for i, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		label := labelKey(row[0])
+		if _, seen := labels[label]; label != "" && !seen {
+			labels[label] = i
+		}
+	}
+
+	if _, ok := labels[labelKey("Month Of:")]; !ok {
+		return nil
+	}
+
+	sheet := &ReportSheet{Sheet: name}
+
+	value := func(label, field string, required bool) (string, string) {
+		index, ok := labels[labelKey(label)]
+		if !ok {
+			if required {
+				sheet.addError("", field, fmt.Sprintf("label %q not found", label))
+			}
+			return "", ""
+		}
+
+		cell := cellName(2, index+1)
+		text := ""
+		if len(rows[index]) > 1 {
+			text = unwrapCellText(rows[index][1])
+		}
+		return strings.TrimSpace(text), cell
+	}
+
+	sheet.MonthOf, sheet.MonthCell = value("Month Of:", "month_of", true)
+	sheet.WorkerName, sheet.WorkerCell = value("Worker Name:", "worker_name", true)
+	sheet.AreaName, sheet.AreaCell = value("Area Of Assignment:", "area_of_assignment", false)
+	sheet.ChurchName, sheet.ChurchCell = value("Name Of Church:", "name_of_church", false)
+
+	if sheet.MonthOf == "" {
+		sheet.addError(sheet.MonthCell, "month_of", "month must not be empty")
+	} else if period, err := model.ParsePeriod(sheet.MonthOf); err != nil {
+		sheet.addError(sheet.MonthCell, "month_of", err.Error())
+	} else {
+		sheet.Report.MonthOf = period
+	}
+
+	if sheet.WorkerName == "" && sheet.WorkerCell != "" {
+		sheet.addError(sheet.WorkerCell, "worker_name", "worker must not be empty")
+	}
+
+	sheet.Report.Activities = model.ActivityValues{}
+	for _, activity := range catalog {
+		index, ok := labels[labelKey(activity.Label)]
+		if !ok {
+			continue
+		}
+
+		if values := parseWeeks(sheet, activity.Key, rows[index], index+1); len(values) > 0 {
+			sheet.Report.Activities[activity.Key] = values
+		}
+	}
+
+	names, _ := value("Names:", "names", false)
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			sheet.Report.Names = append(sheet.Report.Names, name)
+		}
+	}
+
+	sheet.Report.NarrativeReport, _ = value("Narrative Report:", "narrative_report", true)
+	sheet.Report.ChallengesAndProblemEncountered, _ = value("Challenges/\nProblems encountered:", "challenges_and_problem_encountered", true)
+	sheet.Report.PrayerRequest, _ = value("Prayer Requests:", "prayer_request", true)
+
+	return sheet
+}
+
+// parseWeeks reads the Week 1..5 cells of an activity row.
+func parseWeeks(sheet *ReportSheet, key string, row []string, rowNumber int) []int {
+	cells := []string{}
+	if len(row) > 1 {
+		cells = row[1:]
+	}
+
+	return parseWeekValues(cells, func(week int, message string) {
+		sheet.addError(cellName(week+1, rowNumber), key, message)
+	})
+}
+
+// parseWeekValues reads up to model.ReportWeeks weekly values. Trailing blank
+// weeks are left out, as in a report entered through the API; a blank week
+// followed by a filled one counts as zero. Values that are not whole,
+// non-negative numbers are passed to fail and skipped.
+func parseWeekValues(cells []string, fail func(week int, message string)) []int {
+	values := []int{}
+	blanks := 0
+
+	for week := 1; week <= model.ReportWeeks; week++ {
+		text := ""
+		if week <= len(cells) {
+			text = strings.TrimSpace(cells[week-1])
+		}
+
+		if text == "" {
+			blanks++
+			continue
+		}
+
+		number, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", ""), 64)
+		if err != nil || number != math.Trunc(number) {
+			fail(week, fmt.Sprintf("week %d must be a whole number, got %q", week, text))
+			continue
+		}
+		if number < 0 {
+			fail(week, fmt.Sprintf("week %d must not be negative", week))
+			continue
+		}
+
+		for ; blanks > 0; blanks-- {
+			values = append(values, 0)
+		}
+		values = append(values, int(number))
+	}
+
+	return values
+}
+
+// labelKey reduces a label to its letters and digits, so "Month Of:",
+// "month of" and "Sermon/\nMessage Preached:" match regardless of spacing.
+func labelKey(label string) string {
+	var key strings.Builder
+	for _, r := range strings.ToLower(label) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			key.WriteRune(r)
+		}
+	}
+	return key.String()
+}
+
+// unwrapCellText removes the line breaks AddRow inserts after every
+// addRowWrapWidth bytes of the original text, keeping the ones the author
+// typed.
+func unwrapCellText(value string) string {
+	if !strings.Contains(value, "\n") {
+		return value
+	}
+
+	var text strings.Builder
+	offset := 0
+	inserted := false
+
+	for _, r := range value {
+		if r == '\n' && inserted {
+			inserted = false
+			continue
+		}
+		inserted = false
+
+		text.WriteRune(r)
+		if (offset+1)%addRowWrapWidth == 0 {
+			inserted = true
+		}
+		offset += utf8.RuneLen(r)
+	}
+
+	return text.String()
+}
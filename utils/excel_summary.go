@@ -0,0 +1,342 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"reports/model"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	consolidatedSheet = "Consolidated"
+
+	// summaryFirstColumn is where the activity columns start, after area,
+	// church and worker.
+	summaryFirstColumn = 4
+	summaryHeaderRows  = 4
+)
+
+// SummaryWorkbook writes the consolidated monthly sheet: one row per worker
+// with the total and average of every activity, a subtotal row after each
+// area and a grand total at the bottom. Totals are SUBTOTAL formulas, so the
+// grand total skips the area subtotals and the sheet stays correct when
+// rows are filtered or edited.
+type SummaryWorkbook struct {
+	file    *excelize.File
+	sheet   *excelize.StreamWriter
+	styles  summaryStyles
+	catalog model.ActivityCatalog
+	row     int
+
+	area      string
+	areaStart int
+	areaSum   summaryTally
+	grandSum  summaryTally
+	hasRows   bool
+}
+
+type summaryStyles struct {
+	title    int
+	header   int
+	text     int
+	number   int
+	average  int
+	subtotal int
+	subAvg   int
+	total    int
+	totalAvg int
+}
+
+// summaryTally keeps the running values behind the SUBTOTAL formulas so they
+// can be written as the cached result of each formula.
+type summaryTally struct {
+	totals   []float64
+	averages []float64
+	rows     int
+}
+
+func newSummaryTally(activities int) summaryTally {
+	return summaryTally{totals: make([]float64, activities), averages: make([]float64, activities)}
+}
+
+func (t *summaryTally) add(activities []ReportActivity) {
+	for i, activity := range activities {
+		t.totals[i] += float64(activityTotal(activity.Values))
+		t.averages[i] += activity.Average
+	}
+	t.rows++
+}
+
+func (t *summaryTally) average(i int) float64 {
+	if t.rows == 0 {
+		return 0
+	}
+	return t.averages[i] / float64(t.rows)
+}
+
+// NewSummaryWorkbook starts a consolidated sheet for period with a total and
+// average column pair for every activity of catalog.
+func NewSummaryWorkbook(period model.Period, catalog model.ActivityCatalog) (*SummaryWorkbook, error) {
+	file := excelize.NewFile()
+
+	if err := file.SetSheetName("Sheet1", consolidatedSheet); err != nil {
+		return nil, err
+	}
+
+	styles, err := newSummaryStyles(file)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := file.NewStreamWriter(consolidatedSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	activities := len(catalog)
+	workbook := &SummaryWorkbook{
+		file:     file,
+		sheet:    sheet,
+		styles:   styles,
+		catalog:  catalog,
+		areaSum:  newSummaryTally(activities),
+		grandSum: newSummaryTally(activities),
+	}
+
+	if err := workbook.writeHeader(period); err != nil {
+		return nil, err
+	}
+
+	return workbook, nil
+}
+
+func newSummaryStyles(file *excelize.File) (summaryStyles, error) {
+	var styles summaryStyles
+
+	bold := &excelize.Font{Bold: true}
+	subtotalFill := excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFF2CC"}}
+	totalFill := excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}
+
+	definitions := []struct {
+		target *int
+		style  *excelize.Style
+	}{
+		{&styles.title, &excelize.Style{Font: &excelize.Font{Bold: true, Size: 13}}},
+		{&styles.header, &excelize.Style{
+			Font:      &excelize.Font{Bold: true, Color: "FFFFFF"},
+			Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"000000"}},
+			Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+		}},
+		{&styles.text, &excelize.Style{}},
+		{&styles.number, &excelize.Style{NumFmt: 3}},
+		{&styles.average, &excelize.Style{NumFmt: 4}},
+		{&styles.subtotal, &excelize.Style{Font: bold, Fill: subtotalFill, NumFmt: 3}},
+		{&styles.subAvg, &excelize.Style{Font: bold, Fill: subtotalFill, NumFmt: 4}},
+		{&styles.total, &excelize.Style{Font: bold, Fill: totalFill, NumFmt: 3}},
+		{&styles.totalAvg, &excelize.Style{Font: bold, Fill: totalFill, NumFmt: 4}},
+	}
+
+	for _, definition := range definitions {
+		id, err := file.NewStyle(definition.style)
+		if err != nil {
+			return styles, err
+		}
+		*definition.target = id
+	}
+
+	return styles, nil
+}
+
+func (w *SummaryWorkbook) writeHeader(period model.Period) error {
+	labels := summaryActivityLabels(w.catalog)
+	lastColumn := summaryFirstColumn + 2*len(labels) - 1
+
+	if err := w.sheet.SetPanes(&excelize.Panes{
+		Freeze:      true,
+		XSplit:      summaryFirstColumn - 1,
+		YSplit:      summaryHeaderRows,
+		TopLeftCell: cellName(summaryFirstColumn, summaryHeaderRows+1),
+		ActivePane:  "bottomRight",
+	}); err != nil {
+		return err
+	}
+
+	if err := w.sheet.SetColWidth(1, summaryFirstColumn-1, 25); err != nil {
+		return err
+	}
+	if err := w.sheet.SetColWidth(summaryFirstColumn, lastColumn, 12); err != nil {
+		return err
+	}
+
+	if err := w.sheet.SetRow("A1", []interface{}{
+		excelize.Cell{StyleID: w.styles.title, Value: "CONSOLIDATED NATIONAL WORKERS' MONTHLY REPORT"},
+	}); err != nil {
+		return err
+	}
+
+	if err := w.sheet.SetRow("A2", []interface{}{
+		excelize.Cell{StyleID: w.styles.title, Value: period.Label()},
+	}); err != nil {
+		return err
+	}
+
+	activityRow := []interface{}{
+		excelize.Cell{StyleID: w.styles.header, Value: "Area Of Assignment"},
+		excelize.Cell{StyleID: w.styles.header, Value: "Name Of Church"},
+		excelize.Cell{StyleID: w.styles.header, Value: "Worker Name"},
+	}
+	measureRow := []interface{}{
+		excelize.Cell{StyleID: w.styles.header},
+		excelize.Cell{StyleID: w.styles.header},
+		excelize.Cell{StyleID: w.styles.header},
+	}
+	for _, label := range labels {
+		activityRow = append(activityRow, excelize.Cell{StyleID: w.styles.header, Value: label}, excelize.Cell{StyleID: w.styles.header})
+		measureRow = append(measureRow, excelize.Cell{StyleID: w.styles.header, Value: "Total"}, excelize.Cell{StyleID: w.styles.header, Value: "Average"})
+	}
+
+	if err := w.sheet.SetRow("A3", activityRow, excelize.RowOpts{Height: 45}); err != nil {
+		return err
+	}
+	if err := w.sheet.SetRow("A4", measureRow); err != nil {
+		return err
+	}
+
+	for column := 1; column < summaryFirstColumn; column++ {
+		if err := w.sheet.MergeCell(cellName(column, 3), cellName(column, 4)); err != nil {
+			return err
+		}
+	}
+	for i := range labels {
+		column := summaryFirstColumn + 2*i
+		if err := w.sheet.MergeCell(cellName(column, 3), cellName(column+1, 3)); err != nil {
+			return err
+		}
+	}
+
+	w.row = summaryHeaderRows
+	return nil
+}
+
+// AddReport adds a worker row. Reports must arrive grouped by area.
+func (w *SummaryWorkbook) AddReport(report *model.Report) error {
+	if w.hasRows && report.AreaOfAssignment != w.area {
+		if err := w.writeAreaSubtotal(); err != nil {
+			return err
+		}
+	}
+
+	if !w.hasRows || report.AreaOfAssignment != w.area {
+		w.area = report.AreaOfAssignment
+		w.areaStart = w.row + 1
+		w.areaSum = newSummaryTally(len(w.areaSum.totals))
+		w.hasRows = true
+	}
+
+	activities := ReportActivities(w.catalog, report)
+	values := []interface{}{
+		excelize.Cell{StyleID: w.styles.text, Value: report.AreaOfAssignment},
+		excelize.Cell{StyleID: w.styles.text, Value: report.NameOfChurch},
+		excelize.Cell{StyleID: w.styles.text, Value: report.WorkerName},
+	}
+	for _, activity := range activities {
+		values = append(values,
+			excelize.Cell{StyleID: w.styles.number, Value: activityTotal(activity.Values)},
+			excelize.Cell{StyleID: w.styles.average, Value: activity.Average},
+		)
+	}
+
+	w.row++
+	if err := w.sheet.SetRow(cellName(1, w.row), values); err != nil {
+		return err
+	}
+
+	w.areaSum.add(activities)
+	w.grandSum.add(activities)
+	return nil
+}
+
+func (w *SummaryWorkbook) writeAreaSubtotal() error {
+	return w.writeTotalRow(w.area+" Subtotal", w.areaStart, w.row, w.areaSum, w.styles.subtotal, w.styles.subAvg)
+}
+
+func (w *SummaryWorkbook) writeTotalRow(label string, first, last int, tally summaryTally, totalStyle, averageStyle int) error {
+	values := []interface{}{
+		excelize.Cell{StyleID: totalStyle, Value: label},
+		excelize.Cell{StyleID: totalStyle},
+		excelize.Cell{StyleID: totalStyle, Value: fmt.Sprintf("Workers: %d", tally.rows)},
+	}
+
+	for i := range tally.totals {
+		totalColumn := summaryFirstColumn + 2*i
+		values = append(values,
+			excelize.Cell{StyleID: totalStyle, Formula: subtotalFormula(9, totalColumn, first, last), Value: tally.totals[i]},
+			excelize.Cell{StyleID: averageStyle, Formula: subtotalFormula(1, totalColumn+1, first, last), Value: tally.average(i)},
+		)
+	}
+
+	w.row++
+	return w.sheet.SetRow(cellName(1, w.row), values)
+}
+
+// WriteTo closes the last area, adds the grand total and writes the workbook
+// to out.
+func (w *SummaryWorkbook) WriteTo(out io.Writer) (int64, error) {
+	first := summaryHeaderRows + 1
+
+	if w.hasRows {
+		if err := w.writeAreaSubtotal(); err != nil {
+			return 0, err
+		}
+	}
+
+	// SUBTOTAL ignores the area subtotal rows inside the range, so the grand
+	// total can simply cover every row above it.
+	if err := w.writeTotalRow("GRAND TOTAL", first, w.row, w.grandSum, w.styles.total, w.styles.totalAvg); err != nil {
+		return 0, err
+	}
+
+	if err := w.sheet.Flush(); err != nil {
+		return 0, err
+	}
+
+	return w.file.WriteTo(out)
+}
+
+// Close removes the temporary files used by the stream writer.
+func (w *SummaryWorkbook) Close() error {
+	return w.file.Close()
+}
+
+// summaryActivityLabels returns the activity labels as column headings,
+// without the trailing colon and line breaks of the printed report.
+func summaryActivityLabels(catalog model.ActivityCatalog) []string {
+	activities := ReportActivities(catalog, &model.Report{})
+	labels := make([]string, len(activities))
+	for i, activity := range activities {
+		labels[i] = strings.TrimSuffix(strings.ReplaceAll(activity.Label, "\n", ""), ":")
+	}
+	return labels
+}
+
+func activityTotal(values []int) int {
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+func subtotalFormula(function, column, first, last int) string {
+	if last < first {
+		return "0"
+	}
+	return fmt.Sprintf("SUBTOTAL(%d,%s:%s)", function, cellName(column, first), cellName(column, last))
+}
+
+func cellName(column, row int) string {
+	name, _ := excelize.CoordinatesToCellName(column, row)
+	return name
+}
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"reports/model"
+
+	"github.com/tealeg/xlsx"
+	"github.com/xuri/excelize/v2"
+)
+
+const templateSheet = "Report"
+
+// WriteReportTemplate writes a blank report for report's worker and month in
+// the layout of AddReportToSheet, so a filled-in template can be read back by
+// ParseReportWorkbook. Only the week cells, names and text sections can be
+// edited; the week cells accept whole numbers from zero up and the Average
+// column follows them with a formula. Only the active activities of catalog
+// are listed.
+func WriteReportTemplate(out io.Writer, catalog model.ActivityCatalog, report *model.Report) error {
+	activities := catalog.Active()
+
+	blank := model.Report{
+		MonthOf:          report.MonthOf,
+		WorkerName:       report.WorkerName,
+		AreaOfAssignment: report.AreaOfAssignment,
+		NameOfChurch:     report.NameOfChurch,
+	}
+
+	// Lay the sheet out with the export styles, then reopen it with excelize,
+	// which can protect the sheet and validate cells
+	layout := xlsx.NewFile()
+	sheet, err := layout.AddSheet(templateSheet)
+	if err != nil {
+		return err
+	}
+	AddReportToSheet(sheet, activities, &blank)
+
+	var buffer bytes.Buffer
+	if err := layout.Write(&buffer); err != nil {
+		return err
+	}
+
+	file, err := excelize.OpenReader(&buffer)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(templateSheet)
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]int{}
+	for i, row := range rows {
+		if len(row) > 0 && row[0] != "" {
+			labels[labelKey(row[0])] = i + 1
+		}
+	}
+
+	unlocked := map[int]int{}
+	unlock := func(cell string) error {
+		styleId, err := file.GetCellStyle(templateSheet, cell)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := unlocked[styleId]; !ok {
+			style, err := file.GetStyle(styleId)
+			if err != nil {
+				return err
+			}
+			style.Protection = &excelize.Protection{Locked: false}
+
+			unlocked[styleId], err = file.NewStyle(style)
+			if err != nil {
+				return err
+			}
+		}
+
+		return file.SetCellStyle(templateSheet, cell, cell, unlocked[styleId])
+	}
+
+	for _, activity := range ReportActivities(activities, &blank) {
+		row, ok := labels[labelKey(activity.Label)]
+		if !ok {
+			return fmt.Errorf("template has no row for %s", activity.Key)
+		}
+
+		for week := 1; week <= model.ReportWeeks; week++ {
+			if err := unlock(cellName(week+1, row)); err != nil {
+				return err
+			}
+		}
+
+		first, last := cellName(2, row), cellName(model.ReportWeeks+1, row)
+		formula := fmt.Sprintf("IF(COUNT(%s:%s)=0,0,AVERAGE(%s:%s))", first, last, first, last)
+		if err := file.SetCellFormula(templateSheet, cellName(model.ReportWeeks+2, row), formula); err != nil {
+			return err
+		}
+
+		validation := excelize.NewDataValidation(true)
+		validation.Sqref = first + ":" + last
+		if err := validation.SetRange(0, math.MaxInt32, excelize.DataValidationTypeWhole, excelize.DataValidationOperatorBetween); err != nil {
+			return err
+		}
+		validation.SetError(excelize.DataValidationErrorStyleStop, "Invalid value", "Enter a whole number of zero or more.")
+		if err := file.AddDataValidation(templateSheet, validation); err != nil {
+			return err
+		}
+	}
+
+	for _, label := range []string{"Names:", "Narrative Report:", "Challenges/\nProblems encountered:", "Prayer Requests:"} {
+		row, ok := labels[labelKey(label)]
+		if !ok {
+			return fmt.Errorf("template has no row for %q", label)
+		}
+		if err := unlock(cellName(2, row)); err != nil {
+			return err
+		}
+	}
+
+	err = file.ProtectSheet(templateSheet, &excelize.SheetProtectionOptions{
+		SelectLockedCells:   true,
+		SelectUnlockedCells: true,
+		FormatColumns:       true,
+		FormatRows:          true,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = file.WriteTo(out)
+	return err
+}
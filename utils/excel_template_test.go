@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"bytes"
+	"reflect"
+	"reports/model"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var testCatalog = model.ActivityCatalog{
+	{Id: 1, Key: "worship_service", Label: "Worship Service", Category: model.ActivityCategoryAttendance, Active: true},
+	{Id: 2, Key: "family_days", Label: "Family Days", Category: model.ActivityCategoryAttendance},
+	{Id: 3, Key: "home_visited", Label: "Home Visited", Category: model.ActivityCategoryPersonalMinistry, Active: true},
+}
+
+func TestReportTemplateRoundTrip(t *testing.T) {
+	monthOf, _ := model.ParsePeriod("2024-03")
+	report := &model.Report{MonthOf: monthOf, WorkerName: "Juan Dela Cruz", AreaOfAssignment: "Luzon", NameOfChurch: "Grace Church"}
+
+	var template bytes.Buffer
+	if err := WriteReportTemplate(&template, testCatalog, report); err != nil {
+		t.Fatalf("WriteReportTemplate() error = %v", err)
+	}
+
+	// Fill in the template the way a worker would
+	file, err := excelize.OpenReader(&template)
+	if err != nil {
+		t.Fatalf("cannot open template: %v", err)
+	}
+	rows, _ := file.GetRows(templateSheet)
+	for i, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		switch labelKey(row[0]) {
+		case labelKey("Family Days:"):
+			t.Fatalf("template lists the inactive activity on row %d", i+1)
+		case labelKey("Worship Service:"):
+			file.SetCellInt(templateSheet, cellName(2, i+1), 40)
+			file.SetCellInt(templateSheet, cellName(4, i+1), 42)
+		case labelKey("Narrative Report:"):
+			file.SetCellStr(templateSheet, cellName(2, i+1), "A good month")
+		}
+	}
+
+	var filled bytes.Buffer
+	if _, err := file.WriteTo(&filled); err != nil {
+		t.Fatalf("cannot write filled template: %v", err)
+	}
+	file.Close()
+
+	sheets, err := ParseReportWorkbook(&filled, testCatalog)
+	if err != nil {
+		t.Fatalf("ParseReportWorkbook() error = %v", err)
+	}
+	if len(sheets) != 1 {
+		t.Fatalf("ParseReportWorkbook() returned %d sheets, want 1", len(sheets))
+	}
+
+	sheet := sheets[0]
+	if len(sheet.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", sheet.Errors)
+	}
+	if sheet.Report.MonthOf != monthOf || sheet.WorkerName != report.WorkerName || sheet.AreaName != report.AreaOfAssignment || sheet.ChurchName != report.NameOfChurch {
+		t.Fatalf("details = %s, %q, %q, %q", sheet.Report.MonthOf, sheet.WorkerName, sheet.AreaName, sheet.ChurchName)
+	}
+	if want := (model.ActivityValues{"worship_service": {40, 0, 42}}); !reflect.DeepEqual(sheet.Report.Activities, want) {
+		t.Fatalf("activities = %v, want %v", sheet.Report.Activities, want)
+	}
+	if sheet.Report.NarrativeReport != "A good month" {
+		t.Fatalf("narrative report = %q", sheet.Report.NarrativeReport)
+	}
+}
@@ -0,0 +1,339 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"reports/model"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const summarySheet = "Summary"
+
+// ReportWorkbook writes many reports into one workbook: a summary sheet
+// listing them and one sheet per report in the layout of AddReportToSheet.
+// Sheets are written through excelize stream writers, which spill to
+// temporary files instead of keeping every row in memory.
+type ReportWorkbook struct {
+	file       *excelize.File
+	catalog    model.ActivityCatalog
+	summary    *excelize.StreamWriter
+	styles     workbookStyles
+	summaryRow int
+	sheetNames map[string]bool
+}
+
+type workbookStyles struct {
+	orgName        int
+	title          int
+	label          int
+	text           int
+	attendanceHead int
+	columnHead     int
+	number         int
+	average        int
+	link           int
+}
+
+func NewReportWorkbook(catalog model.ActivityCatalog) (*ReportWorkbook, error) {
+	file := excelize.NewFile()
+
+	if err := file.SetSheetName("Sheet1", summarySheet); err != nil {
+		return nil, err
+	}
+
+	styles, err := newWorkbookStyles(file)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := file.NewStreamWriter(summarySheet)
+	if err != nil {
+		return nil, err
+	}
+
+	workbook := &ReportWorkbook{
+		file:       file,
+		catalog:    catalog,
+		summary:    summary,
+		styles:     styles,
+		sheetNames: map[string]bool{summarySheet: true},
+	}
+
+	if err := workbook.writeSummaryHeader(); err != nil {
+		return nil, err
+	}
+
+	return workbook, nil
+}
+
+func newWorkbookStyles(file *excelize.File) (workbookStyles, error) {
+	var styles workbookStyles
+
+	definitions := []struct {
+		target *int
+		style  *excelize.Style
+	}{
+		{&styles.orgName, &excelize.Style{Font: &excelize.Font{Bold: true, Size: 15, Color: "0000FF"}}},
+		{&styles.title, &excelize.Style{Font: &excelize.Font{Bold: true, Size: 13}}},
+		{&styles.label, &excelize.Style{Font: &excelize.Font{Bold: true, Size: 12}, Alignment: &excelize.Alignment{Vertical: "top", WrapText: true}}},
+		{&styles.text, &excelize.Style{Alignment: &excelize.Alignment{Vertical: "top", WrapText: true}}},
+		{&styles.attendanceHead, &excelize.Style{
+			Font: &excelize.Font{Bold: true, Size: 12},
+			Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		}},
+		{&styles.columnHead, &excelize.Style{
+			Font: &excelize.Font{Bold: true, Size: 11, Color: "FFFFFF"},
+			Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"000000"}},
+		}},
+		{&styles.number, &excelize.Style{NumFmt: 1}},
+		{&styles.average, &excelize.Style{NumFmt: 2}},
+		{&styles.link, &excelize.Style{Font: &excelize.Font{Color: "0563C1", Underline: "single"}}},
+	}
+
+	for _, definition := range definitions {
+		id, err := file.NewStyle(definition.style)
+		if err != nil {
+			return styles, err
+		}
+		*definition.target = id
+	}
+
+	return styles, nil
+}
+
+func (w *ReportWorkbook) writeSummaryHeader() error {
+	widths := []float64{10, 16, 30, 25, 30, 12, 32}
+	for i, width := range widths {
+		if err := w.summary.SetColWidth(i+1, i+1, width); err != nil {
+			return err
+		}
+	}
+
+	if err := w.summary.SetRow("A1", []interface{}{
+		excelize.Cell{StyleID: w.styles.title, Value: "NATIONAL WORKERS' MONTHLY REPORTS"},
+	}); err != nil {
+		return err
+	}
+
+	headers := []string{"Report ID", "Month Of", "Worker Name", "Area Of Assignment", "Name Of Church", "Status", "Sheet"}
+	row := make([]interface{}, len(headers))
+	for i, header := range headers {
+		row[i] = excelize.Cell{StyleID: w.styles.columnHead, Value: header}
+	}
+
+	if err := w.summary.SetRow("A2", row); err != nil {
+		return err
+	}
+
+	w.summaryRow = 2
+	return nil
+}
+
+// AddReport adds a sheet for report and lists it on the summary sheet.
+func (w *ReportWorkbook) AddReport(report *model.Report) error {
+	name := w.sheetName(report)
+
+	if _, err := w.file.NewSheet(name); err != nil {
+		return err
+	}
+
+	sheet, err := w.file.NewStreamWriter(name)
+	if err != nil {
+		return err
+	}
+
+	if err := w.writeReportSheet(sheet, report); err != nil {
+		return err
+	}
+
+	if err := sheet.Flush(); err != nil {
+		return err
+	}
+
+	w.summaryRow++
+	cell, _ := excelize.CoordinatesToCellName(1, w.summaryRow)
+
+	return w.summary.SetRow(cell, []interface{}{
+		report.Id,
+		report.MonthOf.Label(),
+		report.WorkerName,
+		report.AreaOfAssignment,
+		report.NameOfChurch,
+		report.Status,
+		excelize.Cell{StyleID: w.styles.link, Formula: sheetLinkFormula(name), Value: name},
+	})
+}
+
+func (w *ReportWorkbook) writeReportSheet(sheet *excelize.StreamWriter, report *model.Report) error {
+	if err := sheet.SetColWidth(1, 1, 35); err != nil {
+		return err
+	}
+	if err := sheet.SetColWidth(2, 7, 14); err != nil {
+		return err
+	}
+
+	row := 0
+	next := func(values []interface{}, opts ...excelize.RowOpts) error {
+		row++
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		return sheet.SetRow(cell, values, opts...)
+	}
+	mergeRow := func() error {
+		return sheet.MergeCell(fmt.Sprintf("A%d", row), fmt.Sprintf("G%d", row))
+	}
+	field := func(label, value string) error {
+		if err := next([]interface{}{
+			excelize.Cell{StyleID: w.styles.label, Value: label},
+			excelize.Cell{StyleID: w.styles.text, Value: value},
+		}, excelize.RowOpts{Height: textRowHeight(value)}); err != nil {
+			return err
+		}
+		return sheet.MergeCell(fmt.Sprintf("B%d", row), fmt.Sprintf("G%d", row))
+	}
+
+	if err := next([]interface{}{excelize.Cell{StyleID: w.styles.orgName, Value: "ANG MANANAMPALATAYANG GUMAWA"}}); err != nil {
+		return err
+	}
+	if err := mergeRow(); err != nil {
+		return err
+	}
+
+	if err := next([]interface{}{excelize.Cell{StyleID: w.styles.title, Value: "NATIONAL WORKERS' MONTHLY REPORT"}}); err != nil {
+		return err
+	}
+	if err := mergeRow(); err != nil {
+		return err
+	}
+
+	for _, item := range [][2]string{
+		{"Month Of:", report.MonthOf.Label()},
+		{"Worker Name:", report.WorkerName},
+		{"Area Of Assignment:", report.AreaOfAssignment},
+		{"Name Of Church:", report.NameOfChurch},
+	} {
+		if err := field(item[0], item[1]); err != nil {
+			return err
+		}
+	}
+
+	if err := next([]interface{}{excelize.Cell{StyleID: w.styles.attendanceHead, Value: "WEEKLY ATTENDANCE"}}); err != nil {
+		return err
+	}
+	if err := mergeRow(); err != nil {
+		return err
+	}
+
+	headers := []string{"Activities", "Week 1", "Week 2", "Week 3", "Week 4", "Week 5", "Average"}
+	headerRow := make([]interface{}, len(headers))
+	for i, header := range headers {
+		headerRow[i] = excelize.Cell{StyleID: w.styles.columnHead, Value: header}
+	}
+	if err := next(headerRow); err != nil {
+		return err
+	}
+
+	for _, activity := range ReportActivities(w.catalog.ForReport(report), report) {
+		values := []interface{}{excelize.Cell{StyleID: w.styles.label, Value: activity.Label}}
+		for week := 0; week < model.ReportWeeks; week++ {
+			if week < len(activity.Values) {
+				values = append(values, excelize.Cell{StyleID: w.styles.number, Value: activity.Values[week]})
+			} else {
+				values = append(values, nil)
+			}
+		}
+		values = append(values, excelize.Cell{StyleID: w.styles.average, Value: activity.Average})
+
+		if err := next(values); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range [][2]string{
+		{"Names:", strings.Join(report.Names, ", ")},
+		{"Narrative Report:", report.NarrativeReport},
+		{"Challenges/\nProblems encountered:", report.ChallengesAndProblemEncountered},
+		{"Prayer Requests:", report.PrayerRequest},
+	} {
+		if err := field(item[0], item[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteTo finishes the workbook and writes it to out.
+func (w *ReportWorkbook) WriteTo(out io.Writer) (int64, error) {
+	if err := w.summary.Flush(); err != nil {
+		return 0, err
+	}
+
+	w.file.SetActiveSheet(0)
+	return w.file.WriteTo(out)
+}
+
+// Close removes the temporary files used by the stream writers.
+func (w *ReportWorkbook) Close() error {
+	return w.file.Close()
+}
+
+// sheetName names a report's sheet after its worker and month, within the
+// 31 characters Excel allows and unique within the workbook.
+func (w *ReportWorkbook) sheetName(report *model.Report) string {
+	base := sanitizeSheetName(fmt.Sprintf("%s %s", report.MonthOf, report.WorkerName))
+	if base == "" {
+		base = fmt.Sprintf("Report %d", report.Id)
+	}
+
+	name := truncateRunes(base, 31)
+	for i := 2; w.sheetNames[name]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		name = strings.TrimSpace(truncateRunes(base, 31-len(suffix))) + suffix
+	}
+
+	w.sheetNames[name] = true
+	return name
+}
+
+func sanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return ' '
+		}
+		return r
+	}, name)
+
+	return strings.Trim(strings.Join(strings.Fields(name), " "), "'")
+}
+
+func truncateRunes(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
+
+func sheetLinkFormula(sheet string) string {
+	target := "#'" + strings.ReplaceAll(sheet, "'", "''") + "'!A1"
+	return fmt.Sprintf(`HYPERLINK("%s","%s")`, strings.ReplaceAll(target, `"`, `""`), strings.ReplaceAll(sheet, `"`, `""`))
+}
+
+// textRowHeight estimates the height a wrapped text row needs across the
+// merged value columns.
+func textRowHeight(value string) float64 {
+	const charactersPerLine = 90
+
+	lines := 0
+	for _, line := range strings.Split(value, "\n") {
+		lines += len([]rune(line))/charactersPerLine + 1
+	}
+
+	if lines > 27 {
+		lines = 27 // Excel caps a row at 409 points
+	}
+
+	return float64(lines) * 15
+}
@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reports/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportExportColumns names the columns of the flat CSV and NDJSON exports:
+// the report details, then worship_service_w1..w5 and worship_service_average
+// for every activity of catalog, then the free text fields.
+func ReportExportColumns(catalog model.ActivityCatalog) []string {
+	columns := []string{"id", "month_of", "status", "worker_id", "worker_name", "area_id", "area_of_assignment", "church_id", "name_of_church"}
+
+	for _, activity := range catalog {
+		for week := 1; week <= model.ReportWeeks; week++ {
+			columns = append(columns, fmt.Sprintf("%s_w%d", activity.Key, week))
+		}
+		columns = append(columns, activity.Key+"_average")
+	}
+
+	return append(columns, "names", "narrative_report", "challenges_and_problem_encountered", "prayer_request", "created_at", "updated_at")
+}
+
+// reportExportValues returns the values of report in the order of
+// ReportExportColumns. Weeks without a value are nil.
+func reportExportValues(catalog model.ActivityCatalog, report *model.Report) []interface{} {
+	values := []interface{}{
+		report.Id,
+		report.MonthOf.String(),
+		report.Status,
+		report.WorkerId,
+		report.WorkerName,
+		report.AreaId,
+		report.AreaOfAssignment,
+		report.ChurchId,
+		report.NameOfChurch,
+	}
+
+	for _, activity := range ReportActivities(catalog, report) {
+		for week := 0; week < model.ReportWeeks; week++ {
+			if week < len(activity.Values) {
+				values = append(values, activity.Values[week])
+			} else {
+				values = append(values, nil)
+			}
+		}
+		values = append(values, activity.Average)
+	}
+
+	return append(values,
+		strings.Join(report.Names, "; "),
+		report.NarrativeReport,
+		report.ChallengesAndProblemEncountered,
+		report.PrayerRequest,
+		report.CreatedAt.Format(time.RFC3339),
+		report.UpdatedAt.Format(time.RFC3339),
+	)
+}
+
+// ExportIncomplete marks the end of an export that failed after it started.
+const ExportIncomplete = "# export incomplete"
+
+// CSVReportWriter writes reports as CSV rows, flushing after every report so
+// they reach the client as they are read.
+type CSVReportWriter struct {
+	writer  *csv.Writer
+	catalog model.ActivityCatalog
+}
+
+func NewCSVReportWriter(out io.Writer, catalog model.ActivityCatalog) *CSVReportWriter {
+	return &CSVReportWriter{writer: csv.NewWriter(out), catalog: catalog}
+}
+
+func (w *CSVReportWriter) WriteHeader() error {
+	if err := w.writer.Write(ReportExportColumns(w.catalog)); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *CSVReportWriter) WriteReport(report *model.Report) error {
+	values := reportExportValues(w.catalog, report)
+	record := make([]string, len(values))
+
+	for i, value := range values {
+		switch value := value.(type) {
+		case nil:
+			record[i] = ""
+		case int:
+			record[i] = strconv.Itoa(value)
+		case float64:
+			record[i] = strconv.FormatFloat(value, 'f', -1, 64)
+		case string:
+			record[i] = value
+		default:
+			record[i] = fmt.Sprint(value)
+		}
+	}
+
+	if err := w.writer.Write(record); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// WriteError ends an export cut short by err with a row whose only field
+// starts with "# export incomplete", so the file cannot pass for complete.
+func (w *CSVReportWriter) WriteError(err error) error {
+	if err := w.writer.Write([]string{ExportIncomplete + ": " + err.Error()}); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// NDJSONReportWriter writes every report as one flat JSON object per line,
+// with the keys in the order of ReportExportColumns.
+type NDJSONReportWriter struct {
+	out     io.Writer
+	catalog model.ActivityCatalog
+	columns []string
+}
+
+func NewNDJSONReportWriter(out io.Writer, catalog model.ActivityCatalog) *NDJSONReportWriter {
+	return &NDJSONReportWriter{out: out, catalog: catalog, columns: ReportExportColumns(catalog)}
+}
+
+func (w *NDJSONReportWriter) WriteReport(report *model.Report) error {
+	var line bytes.Buffer
+	line.WriteByte('{')
+
+	for i, value := range reportExportValues(w.catalog, report) {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+
+		key, err := json.Marshal(w.columns[i])
+		if err != nil {
+			return err
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(encoded)
+	}
+
+	line.WriteString("}\n")
+
+	_, err := w.out.Write(line.Bytes())
+	return err
+}
+
+// WriteError ends an export cut short by err with an {"error": ...} line.
+func (w *NDJSONReportWriter) WriteError(err error) error {
+	line, marshalErr := json.Marshal(map[string]string{"error": ExportIncomplete + ": " + err.Error()})
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	_, err = w.out.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExportWritersMarkIncompleteFiles(t *testing.T) {
+	cause := errors.New("connection reset")
+
+	var csvOut bytes.Buffer
+	if err := NewCSVReportWriter(&csvOut, testCatalog).WriteError(cause); err != nil {
+		t.Fatalf("CSV WriteError() error = %v", err)
+	}
+	if got, want := csvOut.String(), ExportIncomplete+": connection reset\n"; got != want {
+		t.Errorf("CSV error row = %q, want %q", got, want)
+	}
+
+	var ndjsonOut bytes.Buffer
+	if err := NewNDJSONReportWriter(&ndjsonOut, testCatalog).WriteError(cause); err != nil {
+		t.Fatalf("NDJSON WriteError() error = %v", err)
+	}
+	var line map[string]string
+	if err := json.Unmarshal(ndjsonOut.Bytes(), &line); err != nil || !strings.HasPrefix(line["error"], ExportIncomplete) {
+		t.Errorf("NDJSON error line = %q, want an error object", ndjsonOut.String())
+	}
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"reports/model"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	pdfMargin      = 15.0
+	pdfLineHeight  = 6.0
+	pdfLabelWidth  = 50.0
+	pdfActivityCol = 70.0
+	pdfWeekCol     = 18.0
+	pdfAverageCol  = 20.0
+	pdfPageBottom  = 297 - pdfMargin - 5
+)
+
+// WriteReportPDF renders report on A4 paper in the layout of
+// AddReportToSheet, breaking long text across lines and pages.
+func WriteReportPDF(out io.Writer, catalog model.ActivityCatalog, report *model.Report) error {
+	return newReportPDF(catalog, report).Output(out)
+}
+
+func newReportPDF(catalog model.ActivityCatalog, report *model.Report) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+5)
+	pdf.SetTitle(fmt.Sprintf("National Workers' Monthly Report - %s - %s", report.WorkerName, report.MonthOf.Label()), true)
+	pdf.AliasNbPages("")
+
+	// The core fonts are cp1252; translate so names like "Peñafrancia" print
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(100, 100, 100)
+		pdf.CellFormat(0, 5, tr(fmt.Sprintf("%s - %s", report.WorkerName, report.MonthOf.Label())), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	// Organization name and title
+	pdf.SetFont("Helvetica", "B", 15)
+	pdf.SetTextColor(0, 0, 255)
+	pdf.CellFormat(0, 8, "ANG MANANAMPALATAYANG GUMAWA", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(0, 8, "NATIONAL WORKERS' MONTHLY REPORT", "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	for _, item := range [][2]string{
+		{"Month Of:", report.MonthOf.Label()},
+		{"Worker Name:", report.WorkerName},
+		{"Area Of Assignment:", report.AreaOfAssignment},
+		{"Name Of Church:", report.NameOfChurch},
+	} {
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(pdfLabelWidth, pdfLineHeight, item[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.CellFormat(0, pdfLineHeight, tr(item[1]), "B", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Weekly attendance
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetFillColor(255, 255, 0)
+	pdf.CellFormat(0, 7, "WEEKLY ATTENDANCE", "", 1, "L", true, 0, "")
+
+	// The header row is repeated on every page the table runs over
+	writeTableHeader := func() {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetFillColor(0, 0, 0)
+		pdf.SetTextColor(255, 255, 255)
+		pdf.CellFormat(pdfActivityCol, 7, "Activities", "1", 0, "L", true, 0, "")
+		for week := 1; week <= 5; week++ {
+			pdf.CellFormat(pdfWeekCol, 7, fmt.Sprintf("Week %d", week), "1", 0, "C", true, 0, "")
+		}
+		pdf.CellFormat(pdfAverageCol, 7, "Average", "1", 1, "C", true, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
+	writeTableHeader()
+
+	for _, activity := range ReportActivities(catalog.ForReport(report), report) {
+		if pdf.GetY()+pdfLineHeight > pdfPageBottom {
+			pdf.AddPage()
+			writeTableHeader()
+		}
+
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(pdfActivityCol, pdfLineHeight, tr(strings.ReplaceAll(activity.Label, "\n", " ")), "1", 0, "L", false, 0, "")
+
+		pdf.SetFont("Helvetica", "", 10)
+		for week := 0; week < 5; week++ {
+			value := ""
+			if week < len(activity.Values) {
+				value = fmt.Sprintf("%d", activity.Values[week])
+			}
+			pdf.CellFormat(pdfWeekCol, pdfLineHeight, value, "1", 0, "R", false, 0, "")
+		}
+		pdf.CellFormat(pdfAverageCol, pdfLineHeight, fmt.Sprintf("%.2f", activity.Average), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Names and free text sections, wrapped on word boundaries
+	for _, item := range [][2]string{
+		{"Names:", strings.Join(report.Names, ", ")},
+		{"Narrative Report:", report.NarrativeReport},
+		{"Challenges/Problems Encountered:", report.ChallengesAndProblemEncountered},
+		{"Prayer Requests:", report.PrayerRequest},
+	} {
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(0, pdfLineHeight, item[0], "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, 5, tr(item[1]), "", "L", false)
+		pdf.Ln(3)
+	}
+
+	// Signature block, kept together on one page
+	if pdf.GetY()+25 > pdfPageBottom {
+		pdf.AddPage()
+	}
+	pdf.Ln(12)
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(80, 5, tr(report.WorkerName), "B", 1, "C", false, 0, "")
+	pdf.CellFormat(80, 5, "Signature over printed name", "", 1, "C", false, 0, "")
+
+	return pdf
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"reports/model"
+	"strings"
+	"testing"
+)
+
+var pdfPageObject = regexp.MustCompile(`/Type /Page\b[^s]`)
+
+func TestWriteReportPDFBreaksLongText(t *testing.T) {
+	monthOf, _ := model.ParsePeriod("2024-03")
+	report := &model.Report{
+		MonthOf:          monthOf,
+		WorkerName:       "Juan Dela Cruz",
+		AreaOfAssignment: "Luzon",
+		NameOfChurch:     "Peñafrancia Church",
+		Activities:       model.ActivityValues{"worship_service": {40, 42, 38, 45}},
+		NarrativeReport:  strings.Repeat("We visited the families of the church and prayed with them. ", 200),
+	}
+
+	var out bytes.Buffer
+	if err := WriteReportPDF(&out, testCatalog, report); err != nil {
+		t.Fatalf("WriteReportPDF() error = %v", err)
+	}
+
+	pdf := out.Bytes()
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) || !bytes.HasSuffix(bytes.TrimSpace(pdf), []byte("%%EOF")) {
+		t.Fatalf("output is not a PDF document")
+	}
+	if pages := len(pdfPageObject.FindAll(pdf, -1)); pages < 2 {
+		t.Errorf("got %d pages, want the narrative to run over more than one", pages)
+	}
+}
+
+func TestReportPDFRepeatsTableHeader(t *testing.T) {
+	monthOf, _ := model.ParsePeriod("2024-03")
+	report := &model.Report{MonthOf: monthOf, WorkerName: "Juan Dela Cruz", Activities: model.ActivityValues{}}
+
+	var catalog model.ActivityCatalog
+	for i := 1; i <= 60; i++ {
+		key := fmt.Sprintf("activity_%d", i)
+		catalog = append(catalog, &model.Activity{Id: i, Key: key, Label: fmt.Sprintf("Activity %d", i), Category: model.ActivityCategoryAttendance, Active: true})
+		report.Activities[key] = []int{i}
+	}
+
+	pdf := newReportPDF(catalog, report)
+	pdf.SetCompression(false)
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+
+	// 60 rows fill the first page and run well into the second
+	if headers := bytes.Count(out.Bytes(), []byte("(Week 1)")); headers != 2 {
+		t.Errorf("table header drawn %d times, want once on each of the 2 pages of the table", headers)
+	}
+}
+
+func TestReportPDFEncodesActivityLabels(t *testing.T) {
+	monthOf, _ := model.ParsePeriod("2024-03")
+	report := &model.Report{MonthOf: monthOf, WorkerName: "Juan Dela Cruz", Activities: model.ActivityValues{"baptism": {1}}}
+	catalog := model.ActivityCatalog{{Id: 1, Key: "baptism", Label: "Pagbibinyag/Binyag – Niño", Category: model.ActivityCategoryAttendance, Active: true}}
+
+	pdf := newReportPDF(catalog, report)
+	pdf.SetCompression(false)
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+
+	// The core fonts are cp1252: the dash and ñ are single bytes there
+	if !bytes.Contains(out.Bytes(), []byte("(Pagbibinyag/Binyag \x96 Ni\xf1o")) {
+		t.Errorf("activity label is not encoded in cp1252")
+	}
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+func TestValidateToken(t *testing.T) {
+	valid, err := GenerateToken(time.Hour, "7", testSecret)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	expired, _ := GenerateToken(-time.Minute, "7", testSecret)
+	noSubject, _ := GenerateToken(time.Hour, "", testSecret)
+
+	now := time.Now()
+	claims := jwt.RegisteredClaims{Subject: "7", ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour))}
+	otherMethod, _ := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString([]byte(testSecret))
+	unsigned, _ := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+	tests := []struct {
+		name    string
+		token   string
+		secret  string
+		want    string
+		wantErr bool
+	}{
+		{"valid", valid, testSecret, "7", false},
+		{"wrong secret", valid, "other-secret", "", true},
+		{"expired", expired, testSecret, "", true},
+		{"no subject", noSubject, testSecret, "", true},
+		{"other signing method", otherMethod, testSecret, "", true},
+		{"unsigned", unsigned, testSecret, "", true},
+		{"malformed", "not-a-token", testSecret, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateToken(tt.token, tt.secret)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ValidateToken() = %q, %v, want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}